@@ -34,19 +34,98 @@ pub enum Action {
     ScanJanitor,
     CleanJanitor(Vec<models::JanitorItem>),
     Delete(String),
+    Kill { id: String, signal: String },
     RefreshContainers,
+    Export { id: String, dest: std::path::PathBuf },
+    Rename { id: String, name: String },
+    SaveImage { reference: String, dest: std::path::PathBuf },
+    LoadImage { src: std::path::PathBuf },
+    DumpStats { id: String, shown_cpu: Option<f64> }, // shown_cpu: what the UI currently displays, for comparison
+    SaveLogs { id: String, name: String },
+    RemoveImage { id: String, label: String, force: bool },
+    Prune { containers: bool, images: bool }, // Stopped containers and/or dangling images
+    Batch(Vec<Action>), // Lifecycle actions on several containers, run concurrently
+}
+
+impl Action {
+    // Container lifecycle commands, which go through the app's serialized queue
+    pub fn is_lifecycle(&self) -> bool {
+        matches!(self, Action::Start(_) | Action::Stop(_) | Action::Restart(_) | Action::Delete(_) | Action::Kill { .. } | Action::Batch(_))
+    }
+
+    // Container and past-tense verb of a lifecycle action, for the per-container history
+    pub fn lifecycle_target(&self) -> Option<(&str, &'static str)> {
+        match self {
+            Action::Start(id) => Some((id, "started")),
+            Action::Stop(id) => Some((id, "stopped")),
+            Action::Restart(id) => Some((id, "restarted")),
+            Action::Delete(id) => Some((id, "removed")),
+            Action::Kill { id, .. } => Some((id, "killed")),
+            _ => None,
+        }
+    }
+
+    // Every container and verb the action touches: one for a lifecycle action, each member's for a batch
+    pub fn lifecycle_targets(&self) -> Vec<(String, &'static str)> {
+        match self {
+            Action::Batch(actions) => actions.iter().flat_map(|a| a.lifecycle_targets()).collect(),
+            _ => self.lifecycle_target().map(|(id, verb)| vec![(id.to_string(), verb)]).unwrap_or_default(),
+        }
+    }
+}
+
+// Sent once per action when it has finished; progress lines ("Removing ...") go on the result
+// channel instead. The queue matches `target` against what it sent, not the message text.
+#[derive(Debug, Clone)]
+pub struct ActionDone {
+    pub targets: Vec<(String, &'static str)>, // Containers and past-tense verbs, for lifecycle actions
+    pub results: Vec<String>, // One result line per target, in the same order
+    pub notify: bool, // Worth the bell/desktop notification; refreshes (F5, resuming from pause) aren't
+    pub message: String,
+}
+
+// The daemon's conflict errors lead with IDs ("conflict: unable to delete 5d0da3dc9764 (must be forced)
+// - image is referenced in multiple repositories"); keep the reason and whether forcing would help
+fn image_removal_error(msg: &str) -> String {
+    let reason = msg.split_once(" - ").map(|(_, r)| r.trim()).unwrap_or(msg);
+    if msg.contains("(cannot be forced)") {
+        format!("{} (stop it first)", reason)
+    } else if msg.contains("(must be forced)") || msg.contains("(must force)") {
+        format!("{} (must be forced)", reason)
+    } else {
+        msg.to_string()
+    }
 }
 
 pub async fn run_action_loop(
     mut rx_action: mpsc::Receiver<Action>,
     tx_action_result: mpsc::Sender<String>,
+    tx_action_done: mpsc::Sender<ActionDone>,
     tx_janitor_items: mpsc::Sender<Vec<models::JanitorItem>>,
     tx_refresh: mpsc::Sender<()>,
-    tx_logs: mpsc::Sender<String>, // Added log channel
+    tx_logs: mpsc::Sender<(bool, String)>, // (is_stderr, line) into the logs panel
+    client: std::sync::Arc<crate::docker::DockerClient>, // The app's client, so --mock answers from the fixture
 ) {
-    let docker = Docker::connect_with_local_defaults().unwrap();
+    // A TLS daemon is reached through the relay, which does the TLS that bollard isn't set up for
+    let docker = match (crate::docker::tls_relay(), crate::docker::daemon_host()) {
+        (Some(relay), _) => Docker::connect_with_socket(&relay, 120, bollard::API_DEFAULT_VERSION),
+        (None, crate::docker::DaemonHost::Unix(path)) => Docker::connect_with_socket(&path, 120, bollard::API_DEFAULT_VERSION),
+        (None, crate::docker::DaemonHost::Tcp(addr)) => Docker::connect_with_http(&format!("tcp://{}", addr), 120, bollard::API_DEFAULT_VERSION),
+    }
+    .unwrap();
     
     while let Some(action) = rx_action.recv().await {
+        let targets = action.lifecycle_targets();
+        let notify = !matches!(action, Action::RefreshContainers);
+        // Only the read-only ones can be answered from the fixture; the rest would reach a real
+        // daemon through bollard or the docker CLI
+        if client.is_mock() && !matches!(action, Action::RefreshContainers | Action::DumpStats { .. } | Action::SaveLogs { .. }) {
+            let message = "Actions are not available in mock mode".to_string();
+            let results = vec![message.clone(); targets.len()];
+            let _ = tx_action_done.send(ActionDone { targets, results, notify, message }).await;
+            continue;
+        }
+        let mut results = None;
         let res = match action {
             Action::RefreshContainers => {
                 let _ = tx_refresh.send(()).await;
@@ -144,23 +223,16 @@ pub async fn run_action_loop(
                 }
                 format!("Janitor finished. Removed {} items.", count)
             }
-             Action::Start(id) => {
-                match docker.start_container(&id, None::<StartContainerOptions>).await {
-                    Ok(_) => format!("Started container {}", &id[..12]),
-                    Err(e) => format!("Failed to start: {}", e),
-                }
+            action @ (Action::Start(_) | Action::Stop(_) | Action::Kill { .. } | Action::Restart(_) | Action::Delete(_)) => {
+                run_lifecycle(&docker, &client, action, &tx_action_result, &tx_refresh).await
             }
-            Action::Stop(id) => {
-                match docker.stop_container(&id, None::<StopContainerOptions>).await {
-                    Ok(_) => format!("Stopped container {}", &id[..12]),
-                    Err(e) => format!("Failed to stop: {}", e),
-                }
-            }
-            Action::Restart(id) => {
-                match docker.restart_container(&id, None::<RestartContainerOptions>).await {
-                    Ok(_) => format!("Restarted container {}", &id[..12]),
-                    Err(e) => format!("Failed to restart: {}", e),
-                }
+            Action::Batch(actions) => {
+                // Side by side rather than through the queue one at a time; each still reports its own result
+                let runs = actions.into_iter().map(|a| run_lifecycle(&docker, &client, a, &tx_action_result, &tx_refresh));
+                let each = futures_util::future::join_all(runs).await;
+                let summary = each.join("\n");
+                results = Some(each);
+                summary
             }
              Action::Create { image, name, ports, env, cpu, memory, restart } => {
                 // ... (Copy existing logic)
@@ -309,7 +381,7 @@ pub async fn run_action_loop(
                                  let reader = BufReader::new(stdout);
                                  for line in reader.lines() {
                                      if let Ok(l) = line {
-                                         let _ = tx.send(format!("[BUILD] {}", l)).await;
+                                         let _ = tx.send((false, format!("[BUILD] {}", l))).await;
                                      }
                                  }
                              });
@@ -321,7 +393,7 @@ pub async fn run_action_loop(
                                  let reader = BufReader::new(stderr);
                                  for line in reader.lines() {
                                      if let Ok(l) = line {
-                                         let _ = tx.send(format!("[BUILD ERR] {}", l)).await;
+                                         let _ = tx.send((true, format!("[BUILD ERR] {}", l))).await;
                                      }
                                  }
                              });
@@ -511,14 +583,215 @@ pub async fn run_action_loop(
                     Err(e) => format!("Failed to create: {}", e),
                 }
             }
-            Action::Delete(id) => {
-                let _ = tx_action_result.send(format!("Removing {}...", id)).await;
-                match docker.remove_container(&id, Some(RemoveContainerOptions { force: true, ..Default::default() })).await {
-                    Ok(_) => format!("Removed container {}", &id[..12]),
-                    Err(e) => format!("Failed to remove: {}", e),
+            Action::RemoveImage { id, label, force } => {
+                let _ = tx_action_result.send(format!("Removing image {}...", label)).await;
+                match client.remove_image(&id, force).await {
+                    Ok(_) => format!("Removed image {}", label),
+                    Err(e) => format!("Image removal failed ({}): {}", label, image_removal_error(&e.to_string())),
+                }
+            }
+            Action::Prune { containers, images } => {
+                let _ = tx_action_result.send("Pruning...".to_string()).await;
+                let result = prune(&client, containers, images).await;
+                let _ = tx_refresh.send(()).await;
+                match result {
+                    Ok(summary) => summary,
+                    Err(e) => format!("Prune failed: {}", e),
+                }
+            }
+            Action::Export { id, dest } => {
+                let _ = tx_action_result.send(format!("Exporting {}...", &id[..12.min(id.len())])).await;
+                match export_container(&docker, &id, &dest, &tx_action_result).await {
+                    Ok(size) => format!("Exported to {} ({:.1} MB)", dest.display(), size as f64 / 1024.0 / 1024.0),
+                    Err(e) => format!("Export failed: {}", e),
+                }
+            }
+            Action::DumpStats { id, shown_cpu } => {
+                match dump_stats(&client, &id, shown_cpu).await {
+                    Ok(path) => format!("Stats dumped to {}", path),
+                    Err(e) => format!("Failed to dump stats: {}", e),
+                }
+            }
+            Action::SaveLogs { id, name } => {
+                match save_logs(&client, &id, &name).await {
+                    Ok((path, lines)) => format!("Saved {} log lines to {}", lines, path),
+                    Err(e) => format!("Failed to save logs: {}", e),
+                }
+            }
+            Action::Rename { id, name } => {
+                match client.rename_container(&id, &name).await {
+                    Ok(_) => format!("Renamed container {} to {}", &id[..12.min(id.len())], name),
+                    Err(e) => format!("Failed to rename: {}", e),
+                }
+            }
+            Action::SaveImage { reference, dest } => {
+                let _ = tx_action_result.send(format!("Saving {}...", reference)).await;
+                match client.save_image(&reference, &dest, &tx_action_result).await {
+                    Ok(size) => format!("Saved {} to {} ({:.1} MB)", reference, dest.display(), size as f64 / 1024.0 / 1024.0),
+                    Err(e) => format!("Save failed: {}", e),
+                }
+            }
+            Action::LoadImage { src } => {
+                let _ = tx_action_result.send(format!("Loading {}...", src.display())).await;
+                match client.load_image(&src, &tx_action_result).await {
+                    Ok(msg) => {
+                        let _ = tx_refresh.send(()).await;
+                        if msg.is_empty() { format!("Loaded {}", src.display()) } else { msg }
+                    }
+                    Err(e) => format!("Load failed: {}", e),
                 }
             }
         };
-        let _ = tx_action_result.send(res).await;
+        let results = results.unwrap_or_else(|| vec![res.clone()]);
+        let _ = tx_action_done.send(ActionDone { targets, results, notify, message: res }).await;
+    }
+}
+
+// Start/stop/kill/restart/remove of one container, alone or as part of a batch. Returns the result line.
+async fn run_lifecycle(
+    docker: &Docker,
+    client: &crate::docker::DockerClient,
+    action: Action,
+    tx_action_result: &mpsc::Sender<String>,
+    tx_refresh: &mpsc::Sender<()>,
+) -> String {
+    match action {
+        Action::Start(id) => {
+            match docker.start_container(&id, None::<StartContainerOptions>).await {
+                Ok(_) => format!("Started container {}", &id[..12.min(id.len())]),
+                Err(e) => format!("Failed to start: {}", e),
+            }
+        }
+        Action::Stop(id) => {
+            match docker.stop_container(&id, None::<StopContainerOptions>).await {
+                Ok(_) => format!("Stopped container {}", &id[..12.min(id.len())]),
+                Err(e) => format!("Failed to stop: {}", e),
+            }
+        }
+        Action::Kill { id, signal } => {
+            match client.kill_container(&id, &signal).await {
+                Ok(_) => format!("Killed container {} with {}", &id[..12.min(id.len())], signal),
+                Err(e) => format!("Failed to kill: {}", e),
+            }
+        }
+        Action::Restart(id) => {
+            match docker.restart_container(&id, None::<RestartContainerOptions>).await {
+                Ok(_) => format!("Restarted container {}", &id[..12.min(id.len())]),
+                Err(e) => format!("Failed to restart: {}", e),
+            }
+        }
+        Action::Delete(id) => {
+            let _ = tx_action_result.send(format!("Removing {}...", id)).await;
+            match client.remove_container(&id, false).await {
+                Ok(_) => {
+                    let _ = tx_refresh.send(()).await;
+                    format!("Removed container {}", &id[..12.min(id.len())])
+                }
+                Err(e) => format!("Failed to remove: {}", e),
+            }
+        }
+        // Only lifecycle actions are passed in
+        _ => String::new(),
+    }
+}
+
+// Streams the container filesystem tar to `dest`, reporting progress every few MB. Returns the bytes written.
+async fn export_container(
+    docker: &Docker,
+    id: &str,
+    dest: &std::path::Path,
+    tx_action_result: &mpsc::Sender<String>,
+) -> Result<u64, Box<dyn std::error::Error + Send + Sync>> {
+    use tokio::io::AsyncWriteExt;
+
+    let mut file = tokio::fs::File::create(dest).await?;
+    let mut stream = docker.export_container(id);
+    let mut written: u64 = 0;
+    let mut last_report: u64 = 0;
+
+    while let Some(chunk) = stream.next().await {
+        let chunk = chunk?;
+        file.write_all(&chunk).await?;
+        written += chunk.len() as u64;
+        if written - last_report >= 5 * 1024 * 1024 {
+            last_report = written;
+            let _ = tx_action_result.send(format!("Exporting... {:.1} MB", written as f64 / 1024.0 / 1024.0)).await;
+        }
+    }
+    file.flush().await?;
+
+    Ok(written)
+}
+
+// Writes one raw stats sample plus the figures docktop derives from it, so a bug report shows
+// both what the daemon sent and what we made of it. Returns the file path.
+async fn dump_stats(client: &crate::docker::DockerClient, id: &str, shown_cpu: Option<f64>) -> anyhow::Result<String> {
+    let raw = client.get_stats_json(id).await?;
+    let stats: crate::docker::ContainerStats = serde_json::from_value(raw.clone())?;
+
+    // The one-shot sample carries its own previous reading in precpu_stats
+    let mut previous = stats.clone();
+    previous.cpu_stats = stats.precpu_stats.clone();
+    let cpu = crate::ui::calculate_cpu_usage(&stats, &Some(previous));
+    let usage = stats.memory_usage().unwrap_or(0);
+    let limit = stats.memory_limit().unwrap_or(0);
+
+    let now = chrono::Local::now();
+    let dump = serde_json::json!({
+        "docktop": {
+            "version": env!("CARGO_PKG_VERSION"),
+            "container_id": id,
+            "captured_at": now.to_rfc3339(),
+            "cpu_percent": cpu,
+            "cpu_percent_shown": shown_cpu,
+            "online_cpus": if stats.is_windows() { stats.num_procs.map(|n| n as usize) } else { stats.cpu_stats.cpu_usage.percpu_usage.as_ref().map(|v| v.len()) },
+            "memory_usage_bytes": usage,
+            "memory_limit_bytes": limit,
+            "memory_percent": if limit > 0 { usage as f64 / limit as f64 * 100.0 } else { 0.0 },
+        },
+        "stats": raw,
+    });
+
+    let path = format!("/tmp/docktop_stats_{}_{}.json", &id[..12.min(id.len())], now.format("%Y%m%d%H%M%S"));
+    tokio::fs::write(&path, serde_json::to_string_pretty(&dump)?).await?;
+    Ok(path)
+}
+
+// Containers first, so images only they were using are already dangling for the image prune
+async fn prune(client: &crate::docker::DockerClient, containers: bool, images: bool) -> anyhow::Result<String> {
+    let mut parts = Vec::new();
+    let mut freed = 0;
+    if containers {
+        let report = client.prune_containers().await?;
+        parts.push(format!("{} containers", report.deleted));
+        freed += report.space_reclaimed;
+    }
+    if images {
+        let report = client.prune_images().await?;
+        parts.push(format!("{} images", report.deleted));
+        freed += report.space_reclaimed;
+    }
+    Ok(format!("Pruned {}, freed {:.1} MB", parts.join(" and "), freed as f64 / 1024.0 / 1024.0))
+}
+
+// Writes the container's full log to ./<name>-<timestamp>.log. Returns the path and line count.
+async fn save_logs(client: &crate::docker::DockerClient, id: &str, name: &str) -> anyhow::Result<(String, usize)> {
+    let logs = client.get_logs_full(id).await?;
+    // Names can carry slashes (compose projects, "/name" from the API) that would point elsewhere
+    let file: String = name
+        .trim_start_matches('/')
+        .chars()
+        .map(|c| if c.is_alphanumeric() || c == '-' || c == '_' || c == '.' { c } else { '_' })
+        .collect();
+    let file = if file.trim_matches('.').is_empty() { id.chars().take(12).collect() } else { file };
+    let path = format!("./{}-{}.log", file, chrono::Local::now().format("%Y%m%d%H%M%S"));
+
+    let mut content = String::new();
+    for (_, line) in &logs {
+        content.push_str(line.trim_end_matches('\n'));
+        content.push('\n');
     }
+    tokio::fs::write(&path, content).await
+        .map_err(|e| anyhow::anyhow!("Cannot write {}: {}", path, e))?;
+    Ok((path, logs.len()))
 }
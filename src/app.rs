@@ -1,5 +1,5 @@
 use crossterm::event::KeyCode;
-use crate::docker::{Container, ContainerStats, ContainerInspection};
+use crate::docker::{Container, ContainerStats, ContainerInspection, DockerEvent, ServerInfo};
 use crate::config::Config;
 use std::collections::VecDeque;
 use std::fs;
@@ -18,16 +18,266 @@ pub struct Fish {
     pub speed: f64,
 }
 
+pub struct ExecView {
+    pub command: String,
+    pub lines: Vec<(bool, String)>, // (is_stderr, line)
+    pub exit_code: Option<i64>,
+    pub finished: bool,
+    pub scroll: usize,
+}
+
+#[derive(Clone, Copy, PartialEq)]
+pub enum PromptKind {
+    Exec,      // Command for a one-off exec
+    WatchFile, // Path to `tail -F` inside the container
+    Export,    // Destination for `docker export`
+    SaveImage, // Destination for `docker save`
+    LoadImage, // Source archive for `docker load`
+    Rename,    // New container name
+    LogSearch, // Text to highlight in the logs (empty clears)
+}
+
+// Single-line text input shown over the dashboard; what Enter does depends on the kind
+pub struct Prompt {
+    pub kind: PromptKind,
+    pub input: String,
+}
+
+impl Prompt {
+    pub fn new(kind: PromptKind, input: String) -> Self {
+        Self { kind, input }
+    }
+
+    pub fn title(&self) -> &'static str {
+        match self.kind {
+            PromptKind::Exec => " Exec Command (Enter: Run | Esc: Cancel) ",
+            PromptKind::WatchFile => " Watch File (Enter: Tail | Esc: Cancel) ",
+            PromptKind::Export => " Export To (Enter: Export | Esc: Cancel) ",
+            PromptKind::SaveImage => " Save Image To (Enter: Save | Esc: Cancel) ",
+            PromptKind::LoadImage => " Load Image From (Enter: Load | Esc: Cancel) ",
+            PromptKind::Rename => " Rename Container (Enter: Rename | Esc: Cancel) ",
+            PromptKind::LogSearch => " Search Logs (Enter: Search, empty clears | Esc: Cancel) ",
+        }
+    }
+}
+
+#[derive(Clone, Copy, PartialEq, Debug)]
+pub enum LogMode {
+    Stream, // Follow the log stream
+    Poll,   // Re-fetch the tail every few seconds, for setups where streaming is blocked
+    Tail,   // Bounded tail for stopped containers, re-fetched like Poll; never requested, only reported
+}
+
+impl LogMode {
+    pub fn label(&self) -> &'static str {
+        match self {
+            LogMode::Stream => "STREAM",
+            LogMode::Poll => "POLL",
+            LogMode::Tail => "TAIL (stopped, no follow)",
+        }
+    }
+}
+
+// Steps the log refresh key cycles through, in seconds (0 = off)
+pub const LOG_REFRESH_STEPS: [u64; 3] = [0, 2, 5];
+
+// Reported by the log streamer so an empty logs panel can say why it is empty
+#[derive(Clone, Debug)]
+pub enum LogStatus {
+    Loading,
+    Connected(LogMode),
+    Failed(String),
+    Gone, // The container was removed; not an error worth recording
+}
+
+// Side-by-side stats for two marked containers
+pub struct CompareView {
+    pub ids: [String; 2],
+    pub columns: [CompareColumn; 2],
+}
+
+#[derive(Default)]
+pub struct CompareColumn {
+    pub previous: Option<ContainerStats>,
+    pub current: Option<ContainerStats>,
+    pub cpu_history: Vec<u64>,
+    pub mem_history: Vec<u64>,
+    pub paused: bool,
+}
+
+impl CompareView {
+    pub fn new(ids: [String; 2]) -> Self {
+        Self { ids, columns: [CompareColumn::default(), CompareColumn::default()] }
+    }
+
+    pub fn update(&mut self, id: &str, stats: ContainerStats, paused: bool, history_size: usize) {
+        let idx = match self.ids.iter().position(|i| i == id) {
+            Some(i) => i,
+            None => return,
+        };
+        let col = &mut self.columns[idx];
+        col.paused = paused;
+        col.previous = col.current.take();
+        if paused {
+            // Keep the graphs where they were instead of recording the frozen pre-pause delta
+            col.current = Some(stats);
+            return;
+        }
+        let cpu = crate::ui::calculate_cpu_usage(&stats, &col.previous);
+        let mem = match (stats.memory_usage(), stats.memory_limit()) {
+            (Some(u), Some(l)) if l > 0 => u * 100 / l,
+            _ => 0,
+        };
+        col.cpu_history.push(cpu as u64);
+        col.mem_history.push(mem);
+        if col.cpu_history.len() > history_size { col.cpu_history.remove(0); }
+        if col.mem_history.len() > history_size { col.mem_history.remove(0); }
+        col.current = Some(stats);
+    }
+}
+
+// Signals offered by the kill menu; the first is the default
+pub const KILL_SIGNALS: [&str; 4] = ["SIGKILL", "SIGTERM", "SIGHUP", "SIGINT"];
+
+// Signal picker for the kill key, bound to the container it was opened on
+pub struct SignalMenu {
+    pub id: String,
+    pub selected: usize, // Index into KILL_SIGNALS
+}
+
+// Target picker for the prune key; the estimate fills in once `docker system df` answers
+pub struct PruneMenu {
+    pub selected: usize, // 0 = stopped containers, 1 = dangling images
+    pub containers: bool,
+    pub images: bool,
+    pub estimate: Option<Result<crate::docker::PruneEstimate, String>>, // None while loading
+}
+
+impl PruneMenu {
+    pub fn new() -> Self {
+        Self { selected: 0, containers: true, images: true, estimate: None }
+    }
+
+    pub fn toggle(&mut self, index: usize) {
+        match index {
+            0 => self.containers = !self.containers,
+            _ => self.images = !self.images,
+        }
+    }
+
+    // "3 stopped containers (12.0 MiB)" per picked target; sizes only once estimated
+    pub fn targets(&self, units: &crate::ui::util::ByteUnits) -> Vec<String> {
+        let estimate = self.estimate.as_ref().and_then(|e| e.as_ref().ok());
+        let describe = |what: &str, counted: Option<(usize, u64)>| match counted {
+            Some((n, bytes)) => format!("{} {} ({})", n, what, units.format(bytes)),
+            None => what.to_string(),
+        };
+        let mut targets = Vec::new();
+        if self.containers {
+            targets.push(describe("stopped containers", estimate.map(|e| e.containers)));
+        }
+        if self.images {
+            targets.push(describe("dangling images", estimate.map(|e| e.images)));
+        }
+        targets
+    }
+}
+
+// Raw `docker inspect` of one container, pretty-printed, in a scrollable full-screen overlay
+pub struct InspectView {
+    pub id: String,
+    pub lines: Option<Result<Vec<String>, String>>, // None while loading
+    pub scroll: usize,
+    pub height: usize, // Rows on screen, recorded by the draw
+}
+
+impl InspectView {
+    // Stops with the last line at the bottom instead of scrolling into empty space
+    pub fn max_scroll(&self) -> usize {
+        match &self.lines {
+            Some(Ok(lines)) => lines.len().saturating_sub(self.height),
+            _ => 0,
+        }
+    }
+}
+
+// Header for the containers of one Compose project while the list is grouped
+pub struct ContainerGroup {
+    pub name: String, // Project, or UNGROUPED
+    pub total: usize, // Members passing the scope and filter, shown or not
+    pub first: Option<usize>, // Index of the first member in `containers`; None when collapsed
+}
+
+pub const UNGROUPED: &str = "(ungrouped)";
+
+// A row the cursor can rest on, in list order: a group header (grouped only) or a container
+#[derive(Clone, Copy, PartialEq)]
+enum ListRow {
+    Group(usize), // Index into `groups`
+    Container(usize), // Index into `containers`
+}
+
+// Yes/no question shown over everything else; `action` is dispatched on yes
+pub struct Confirm {
+    pub title: String,
+    pub message: String,
+    pub action: crate::action::Action,
+}
+
+// Most recent failure kept in full, since the toast only has room for its first few words
+pub struct LastError {
+    pub operation: String,
+    pub message: String,
+    pub at: chrono::DateTime<chrono::Local>,
+}
+
+// Latest lifecycle action per container, so its outcome outlives the notification
+pub struct LastAction {
+    pub verb: &'static str, // "started", "stopped", "restarted" or "removed"
+    pub ok: bool,
+    pub at: std::time::Instant,
+}
+
+// Restarts counted for crash-loop detection, and how many within the window make a loop
+pub const CRASH_LOOP_WINDOW: std::time::Duration = std::time::Duration::from_secs(60);
+pub const CRASH_LOOP_RESTARTS: u64 = 2;
+
+// Restarts over the container's lifetime worth flagging in the details, loop or not
+pub const HIGH_RESTART_COUNT: u64 = 5;
+
+// How long a row stays highlighted after its container changes state
+pub const RECENT_CHANGE_HIGHLIGHT: std::time::Duration = std::time::Duration::from_secs(3);
+
+// How long a status toast stays up; a newer message restarts the clock
+pub const STATUS_TTL: std::time::Duration = std::time::Duration::from_secs(4);
+
+pub const EVENT_TYPES: [&str; 5] = ["all", "container", "image", "volume", "network"];
+
+pub struct EventsView {
+    pub filter: usize, // Index into EVENT_TYPES
+    pub scroll: Option<usize>, // None follows the newest event
+}
+
+// Image list shown in place of the containers (fetched when opened and on refresh)
+pub struct ImagesView {
+    pub images: Option<Vec<crate::docker::ImageSummary>>, // None while loading
+    pub error: Option<String>,
+    pub selected: usize,
+}
+
 pub struct App {
-    pub containers: Vec<Container>,
+    pub containers: Vec<Container>, // Shown: scoped, filtered and sorted
+    pub all_containers: Vec<Container>, // As last fetched from the daemon
     pub selected_index: usize,
     pub current_stats: Option<ContainerStats>,
     pub previous_stats: Option<ContainerStats>,
     pub current_inspection: Option<ContainerInspection>,
-    pub logs: VecDeque<String>,
+    pub logs: VecDeque<(bool, String)>, // (is_stderr, line)
     pub is_loading_details: bool,
     pub action_status: Option<(String, std::time::Instant)>,
     pub cpu_history: Vec<(f64, f64)>,
+    pub container_cpu_history: Vec<f64>, // Selected container only, for the details sparkline
+    pub history_run: Option<String>, // StartedAt of the run the histories above were sampled from
     pub net_rx_history: Vec<(f64, f64)>,
     pub net_tx_history: Vec<(f64, f64)>,
     pub x_axis_bounds: [f64; 2],
@@ -42,7 +292,79 @@ pub struct App {
     pub _system: System,
     pub _networks: Networks,
     pub filter_query: String,
+    pub sort: String,  // "name", "cpu", "memory", "status" or "disk"
+    pub scope: String, // "all", "running" or "stopped"
+    pub list_all: bool, // Whether stopped containers are requested from the daemon at all
+    pub list_sizes: bool, // Whether the list asks for layer sizes (Disk column, "disk" sort)
+    pub mock_source: Option<String>, // Fixture file when running with --mock
+    pub follow_newest: bool, // Keep selecting the newest running container until the user navigates
     pub is_typing_filter: bool,
+    pub env_filter: String, // Narrows the env list in the details panel
+    pub is_typing_env_filter: bool,
+    pub env_view: bool, // Side panel shows only the env list, scrolled with env_scroll
+    pub env_scroll: usize,
+    pub env_area_height: usize, // Rows of that list as last drawn, for paging
+    pub reveal_env: bool, // Show secret-looking env values instead of masking them
+    pub server_info: Option<ServerInfo>,
+    pub initialized: bool, // Set once the first container list has arrived
+    pub spinner_frame: usize,
+    pub prompt: Option<Prompt>,
+    pub exec_view: Option<ExecView>,
+    pub watching_file: Option<String>, // File tailed into the logs panel instead of stdout
+    pub show_line_numbers: bool,
+    pub log_wrap: bool,
+    pub list_width: u16, // Percent, see GeneralConfig::list_width; the last runtime adjustment wins
+    pub logs_height: u16, // Percent, see GeneralConfig::logs_height; same
+    pub log_search: String, // Highlighted in the logs and stepped through with n/N; kept across refreshes and containers
+    pub log_anchor: Option<usize>, // Log line the last n/N landed on, where the next step starts from
+    pub log_scroll: usize, // First log line shown while not following
+    pub log_area: (usize, usize), // Width and height inside the logs panel as last drawn, for paging
+    pub log_follow: bool, // Keep the newest log lines in view
+    pub pending_log_follow: Option<String>, // Container restarted via restart-and-follow, awaiting completion
+    pub symbols: &'static crate::theme::icons::Symbols,
+    pub units: crate::ui::util::ByteUnits,
+    pub log_mode: LogMode, // Requested mode; the streamer may fall back to polling on its own
+    pub active_log_mode: LogMode, // What the streamer is actually doing
+    pub log_refresh_secs: u64, // Re-fetch interval while polling or tailing a stopped container (0 = off)
+    pub stats_interval: std::time::Duration, // Cadence of the stats fetchers, fixed at startup
+    pub log_status: LogStatus,
+    pub privileged: std::collections::HashMap<String, Vec<String>>, // Container ID -> privilege warnings
+    pub restart_samples: std::collections::HashMap<String, Vec<(std::time::Instant, u64)>>, // Container ID -> RestartCount whenever it changed
+    pub volume_sizes: std::collections::HashMap<String, u64>, // Volume name -> bytes on disk
+    pub events: VecDeque<DockerEvent>, // Daemon activity feed, collected even while the view is closed
+    pub events_view: Option<EventsView>,
+    pub marked: Vec<String>, // Container IDs marked for comparison (at most two)
+    pub batch: Vec<String>, // Container IDs picked for batch start/stop/restart/remove
+    pub compare_view: Option<CompareView>,
+    pub images_view: Option<ImagesView>,
+    pub confirm: Option<Confirm>,
+    pub signal_menu: Option<SignalMenu>,
+    pub prune_menu: Option<PruneMenu>,
+    pub last_error: Option<LastError>,
+    pub error_view: Option<u16>, // Scroll offset while the last-error overlay is open
+    pub inspect_view: Option<InspectView>,
+    pub action_queue: VecDeque<crate::action::Action>, // Lifecycle actions waiting for the one in flight
+    pub action_in_flight: bool,
+    pub queue_results: Vec<String>, // Results of the current run of queued actions, shown together
+    pub in_flight: Vec<(String, &'static str)>, // Containers and verbs of the action in flight
+    pub last_actions: std::collections::HashMap<String, LastAction>, // Container ID -> latest lifecycle action
+    pub pending_rename: Option<(String, bool)>, // (container ID, was running) until the rename result arrives
+    pub pending_image_removal: Option<(String, String)>, // (image ID, label) until its result arrives, to offer forcing
+    pub show_stats_strip: bool,
+    pub relative_cpu_colors: bool,
+    pub show_ids: bool, // Containers identified by ID rather than name
+    pub group_by_project: bool,
+    pub collapsed_groups: std::collections::HashSet<String>,
+    pub groups: Vec<ContainerGroup>, // In list order; empty unless grouping
+    pub selected_group: Option<String>, // Header the cursor is on instead of a container
+    pub zen: bool, // Header and footer hidden to give the panels every row
+    pub daemon_down: Option<String>, // Why the last container list failed, until one succeeds again
+    pub refresh_paused: bool, // Container list frozen: fetched lists are dropped until resumed
+    pub self_container: Option<String>, // ID or ID prefix of the container docktop runs in
+    pub known_states: std::collections::HashMap<String, String>, // Last seen state of every listed container, before filtering
+    pub state_changed: std::collections::HashMap<String, std::time::Instant>, // When a container last changed state
+    pub strip_stats: std::collections::HashMap<String, (ContainerStats, f64)>, // Container ID -> (last sample, CPU %)
+    pub strip_missing: std::collections::HashSet<String>, // Running containers whose last stats request failed
 }
 
 impl App {
@@ -85,8 +407,30 @@ impl App {
              globe_frames.push(vec!["Animation not found".to_string()]);
         }
 
+        let config = Config::load();
+        let show_line_numbers = config.general.log_line_numbers;
+        let log_wrap = config.general.log_wrap;
+        let ui_state = crate::config::UiState::load();
+        let list_width = ui_state
+            .list_width
+            .unwrap_or(config.general.list_width)
+            .clamp(crate::config::MIN_LIST_WIDTH, crate::config::MAX_LIST_WIDTH);
+        let logs_height = ui_state
+            .logs_height
+            .unwrap_or(config.general.logs_height)
+            .clamp(crate::config::MIN_LOGS_HEIGHT, crate::config::MAX_LOGS_HEIGHT);
+        let show_stats_strip = config.general.stats_strip;
+        let relative_cpu_colors = config.general.cpu_colors == "relative";
+        let show_ids = config.general.show_container_ids;
+        let group_by_project = config.general.group_by_project;
+        let log_refresh_secs = config.general.log_refresh_secs;
+        let symbols = crate::theme::icons::Symbols::from_config(&config.general.symbols);
+        let units = crate::ui::util::ByteUnits::from_config(&config.general.memory_units, &config.general.memory_base);
+        let log_mode = if config.general.log_mode == "poll" { LogMode::Poll } else { LogMode::Stream };
+
         App {
             containers: vec![],
+            all_containers: Vec::new(),
             selected_index: 0,
             current_stats: None,
             previous_stats: None,
@@ -95,12 +439,14 @@ impl App {
             is_loading_details: false,
             action_status: None,
             cpu_history: vec![],
+            container_cpu_history: Vec::new(),
+            history_run: None,
             net_rx_history: vec![],
             net_tx_history: vec![],
             x_axis_bounds: [0.0, 100.0],
             show_details: false,
             net_axis_bounds: [0.0, 100.0],
-            config: Config::load(),
+            config,
             fishes,
             globe_frames,
             wizard: None,
@@ -108,84 +454,882 @@ impl App {
             _system: System::new_all(),
             _networks: Networks::new_with_refreshed_list(),
             filter_query: String::new(),
+            sort: "status".to_string(),
+            scope: "all".to_string(),
+            list_all: true,
+            list_sizes: false,
+            mock_source: None,
+            follow_newest: false,
             is_typing_filter: false,
+            env_filter: String::new(),
+            is_typing_env_filter: false,
+            env_view: false,
+            env_scroll: 0,
+            env_area_height: 0,
+            reveal_env: false,
+            server_info: None,
+            initialized: false,
+            spinner_frame: 0,
+            prompt: None,
+            exec_view: None,
+            watching_file: None,
+            show_line_numbers,
+            log_wrap,
+            list_width,
+            logs_height,
+            log_search: String::new(),
+            log_anchor: None,
+            log_scroll: 0,
+            log_area: (0, 0),
+            log_follow: false,
+            pending_log_follow: None,
+            symbols,
+            units,
+            log_mode,
+            active_log_mode: log_mode,
+            log_refresh_secs,
+            stats_interval: crate::config::DEFAULT_STATS_INTERVAL,
+            log_status: LogStatus::Loading,
+            privileged: std::collections::HashMap::new(),
+            restart_samples: std::collections::HashMap::new(),
+            volume_sizes: std::collections::HashMap::new(),
+            events: VecDeque::with_capacity(500),
+            events_view: None,
+            marked: Vec::new(),
+            batch: Vec::new(),
+            compare_view: None,
+            images_view: None,
+            confirm: None,
+            signal_menu: None,
+            prune_menu: None,
+            last_error: None,
+            error_view: None,
+            inspect_view: None,
+            action_queue: VecDeque::new(),
+            action_in_flight: false,
+            queue_results: Vec::new(),
+            in_flight: Vec::new(),
+            last_actions: std::collections::HashMap::new(),
+            pending_rename: None,
+            pending_image_removal: None,
+            show_stats_strip,
+            relative_cpu_colors,
+            show_ids,
+            group_by_project,
+            collapsed_groups: std::collections::HashSet::new(),
+            groups: Vec::new(),
+            selected_group: None,
+            zen: false,
+            refresh_paused: false,
+            daemon_down: None,
+            self_container: crate::docker::own_container_id(),
+            known_states: std::collections::HashMap::new(),
+            state_changed: std::collections::HashMap::new(),
+            strip_stats: std::collections::HashMap::new(),
+            strip_missing: std::collections::HashSet::new(),
+        }
+    }
+
+    // Initial sort/filter/scope/panel from config, with command-line flags taking precedence
+    pub fn apply_startup(&mut self, overrides: &crate::config::CliOverrides) {
+        let general = &self.config.general;
+        self.sort = overrides.sort.clone().unwrap_or_else(|| general.default_sort.clone());
+        self.filter_query = overrides.filter.clone().unwrap_or_else(|| general.default_filter.clone());
+        self.scope = overrides.scope.clone().unwrap_or_else(|| {
+            // Older configs only have show_all_containers
+            if general.default_scope == "all" && !general.show_all_containers {
+                "running".to_string()
+            } else {
+                general.default_scope.clone()
+            }
+        });
+        self.list_all = overrides.all.unwrap_or(general.list_stopped_containers);
+        // Ranking by disk needs the sizes, whatever the option says
+        self.list_sizes = general.list_container_sizes || self.sort == "disk";
+        self.follow_newest = overrides.follow_newest;
+        self.mock_source = overrides.mock.clone();
+        match overrides.panel.as_deref().unwrap_or(&general.default_panel) {
+            "details" => self.show_details = true,
+            "events" => self.events_view = Some(EventsView { filter: 0, scroll: None }),
+            _ => {}
+        }
+        if self.list_sizes {
+            self.set_action_status("Container sizes are on: listing is slower on hosts with many containers".to_string());
+        }
+        let configured = std::time::Duration::from_millis(self.config.general.stats_interval_ms);
+        let (source, interval) = match &overrides.interval {
+            Some(text) => (format!("--interval {}", text), crate::config::parse_interval(text)),
+            None => (format!("stats_interval_ms = {}", self.config.general.stats_interval_ms), Some(configured)),
+        };
+        self.stats_interval = match interval {
+            Some(i) if i >= crate::config::MIN_STATS_INTERVAL => i,
+            _ => {
+                self.set_action_status(format!(
+                    "Invalid interval: {} (minimum 500ms), using {}",
+                    source,
+                    format_interval(crate::config::DEFAULT_STATS_INTERVAL)
+                ));
+                crate::config::DEFAULT_STATS_INTERVAL
+            }
+        };
+    }
+
+    pub fn update_containers(&mut self, containers: Vec<crate::docker::Container>) {
+        // Remember state transitions so the list can flash the rows that just changed.
+        // Containers seen for the first time aren't a change, unless the list was already populated.
+        let now = std::time::Instant::now();
+        for c in &containers {
+            match self.known_states.get(&c.id) {
+                Some(old) if *old != c.state => { self.state_changed.insert(c.id.clone(), now); }
+                None if self.initialized => { self.state_changed.insert(c.id.clone(), now); }
+                _ => {}
+            }
         }
+        self.known_states = containers.iter().map(|c| (c.id.clone(), c.state.clone())).collect();
+        self.state_changed.retain(|_, t| t.elapsed() < RECENT_CHANGE_HIGHLIGHT);
+
+        // A selected container that vanished leaves its details and logs behind; drop them
+        // before the cursor lands on a neighbour
+        let selected = self.get_selected_container().map(|c| c.id.clone());
+        let gone = selected.map(|id| !containers.iter().any(|c| c.id == id)).unwrap_or(false);
+        self.all_containers = containers;
+        self.refilter();
+        if gone {
+            self.set_loading();
+        }
+        let all = &self.all_containers;
+        self.batch.retain(|id| all.iter().any(|c| &c.id == id));
+        self.last_actions.retain(|id, _| all.iter().any(|c| &c.id == id));
+        // Against every running container, not just the shown ones: the resource alert and the
+        // CPU/memory sort still want samples for what the filter or a folded group hides
+        self.strip_stats.retain(|id, _| all.iter().any(|c| &c.id == id && c.state == "running"));
+        self.strip_missing.retain(|id| all.iter().any(|c| &c.id == id && c.state == "running"));
+        self.initialized = true;
     }
 
-    pub fn update_containers(&mut self, mut containers: Vec<crate::docker::Container>) {
+    // Rebuilds the visible list from the last fetch, so typing a filter narrows it right away.
+    // The cursor stays on the same container when it's still shown, else it's clamped.
+    pub fn refilter(&mut self) {
+        // The container under a selected header too, so leaving the header lands where it was
+        let selected = self.containers.get(self.selected_index).map(|c| c.id.clone());
+        let mut containers = self.all_containers.clone();
+
         // Filter
-        if !self.config.general.show_all_containers {
-            containers.retain(|c| c.state == "running");
+        match self.scope.as_str() {
+            "running" => containers.retain(|c| c.state == "running"),
+            "stopped" => containers.retain(|c| c.state != "running"),
+            _ => {}
         }
         
-        if !self.filter_query.is_empty() {
-            let query = self.filter_query.to_lowercase();
-            containers.retain(|c| {
-                c.names.iter().any(|n| n.to_lowercase().contains(&query)) ||
-                c.image.to_lowercase().contains(&query) ||
-                c.id.to_lowercase().contains(&query)
-            });
+        if !self.filter_query.is_empty() && !self.is_highlight_filter() {
+            containers.retain(|c| self.matches_filter(c));
         }
 
         // Sort
-        match self.config.general.default_sort.as_str() {
+        match self.sort.as_str() {
             "name" => containers.sort_by(|a, b| a.names.first().unwrap_or(&String::new()).cmp(b.names.first().unwrap_or(&String::new()))),
             "status" => containers.sort_by(|a, b| a.state.cmp(&b.state)),
+            "disk" => containers.sort_by(|a, b| b.size_rw.unwrap_or(0).cmp(&a.size_rw.unwrap_or(0))),
+            // Busiest first; stopped containers and ones not sampled yet count as 0
+            "cpu" => {
+                let cpu = |c: &crate::docker::Container| self.strip_stats.get(&c.id).map(|(_, cpu)| *cpu).unwrap_or(0.0);
+                containers.sort_by(|a, b| cpu(b).total_cmp(&cpu(a)));
+            }
+            "memory" => {
+                let mem = |c: &crate::docker::Container| self.strip_stats.get(&c.id).and_then(|(s, _)| s.memory_usage()).unwrap_or(0);
+                containers.sort_by(|a, b| mem(b).cmp(&mem(a)));
+            }
             _ => {}
         }
-        
+
+        // Projects alphabetically with standalone containers last; the stable sort keeps the
+        // order above within each group. Collapsed groups keep their header but no rows.
+        self.groups.clear();
+        if self.group_by_project {
+            containers.sort_by(|a, b| {
+                let key = |c: &crate::docker::Container| (c.compose_project().is_none(), c.compose_project().unwrap_or("").to_string());
+                key(a).cmp(&key(b))
+            });
+            let mut shown = Vec::new();
+            for c in containers {
+                let name = c.compose_project().unwrap_or(UNGROUPED).to_string();
+                if self.groups.last().map(|g| g.name != name).unwrap_or(true) {
+                    self.groups.push(ContainerGroup { name: name.clone(), total: 0, first: None });
+                }
+                if let Some(group) = self.groups.last_mut() {
+                    group.total += 1;
+                    if !self.collapsed_groups.contains(&name) {
+                        group.first.get_or_insert(shown.len());
+                        shown.push(c);
+                    }
+                }
+            }
+            containers = shown;
+        }
+        if self.selected_group.as_ref().map(|name| !self.groups.iter().any(|g| &g.name == name)).unwrap_or(false) {
+            self.selected_group = None;
+        }
+
         self.containers = containers;
+        match selected.and_then(|id| self.containers.iter().position(|c| c.id == id)) {
+            Some(idx) => self.selected_index = idx,
+            None => self.selected_index = self.selected_index.min(self.containers.len().saturating_sub(1)),
+        }
     }
 
-    pub fn next(&mut self) {
-        if !self.containers.is_empty() {
-            self.selected_index = (self.selected_index + 1) % self.containers.len();
-            self.set_loading();
+    // name -> cpu -> memory -> status (-> disk when sizes are listed) -> name
+    pub fn cycle_sort(&mut self) {
+        let mut orders = vec!["name", "cpu", "memory", "status"];
+        if self.list_sizes {
+            orders.push("disk");
         }
+        let next = orders.iter().position(|o| *o == self.sort).map(|i| (i + 1) % orders.len()).unwrap_or(0);
+        self.sort = orders[next].to_string();
     }
 
-    pub fn previous(&mut self) {
-        if !self.containers.is_empty() {
-            if self.selected_index > 0 {
-                self.selected_index -= 1;
-            } else {
-                self.selected_index = self.containers.len() - 1;
+    pub fn sorts_by_usage(&self) -> bool {
+        self.sort == "cpu" || self.sort == "memory"
+    }
+
+    // Per-container stats are sampled for the strip, for usage sorts and for the resource alert
+    pub fn wants_usage_stats(&self) -> bool {
+        let general = &self.config.general;
+        self.show_stats_strip || self.sorts_by_usage() || general.alert_cpu_percent > 0.0 || general.alert_memory_percent > 0.0
+    }
+
+    pub fn is_highlight_filter(&self) -> bool {
+        self.config.general.filter_mode == "highlight"
+    }
+
+    pub fn matches_filter(&self, c: &Container) -> bool {
+        if self.filter_query.is_empty() {
+            return false;
+        }
+        let query = self.filter_query.to_lowercase();
+        c.names.iter().any(|n| n.to_lowercase().contains(&query)) ||
+        c.image.to_lowercase().contains(&query) ||
+        c.id.to_lowercase().contains(&query) ||
+        self.port_matches(c)
+    }
+
+    // The filter is a port number ("8080" or ":8080") that the container publishes or exposes
+    pub fn port_matches(&self, c: &Container) -> bool {
+        let port = match self.filter_query.trim().trim_start_matches(':').parse::<u16>() {
+            Ok(p) => p,
+            Err(_) => return false,
+        };
+        c.ports
+            .as_deref()
+            .unwrap_or(&[])
+            .iter()
+            .any(|p| p.public_port == Some(port) || p.private_port == port)
+    }
+
+    pub fn next_match(&mut self) {
+        self.follow_newest = false;
+        let len = self.containers.len();
+        for step in 1..=len {
+            let idx = (self.selected_index + step) % len;
+            if self.matches_filter(&self.containers[idx]) {
+                if idx != self.selected_index {
+                    self.selected_index = idx;
+                    self.set_loading();
+                }
+                return;
+            }
+        }
+    }
+
+    pub fn previous_match(&mut self) {
+        self.follow_newest = false;
+        let len = self.containers.len();
+        for step in 1..=len {
+            let idx = (self.selected_index + len - step) % len;
+            if self.matches_filter(&self.containers[idx]) {
+                if idx != self.selected_index {
+                    self.selected_index = idx;
+                    self.set_loading();
+                }
+                return;
+            }
+        }
+    }
+
+    // Moves to the next container with a problem, wrapping around; returns its problem
+    pub fn next_problem(&mut self) -> Option<&'static str> {
+        self.follow_newest = false;
+        let len = self.containers.len();
+        for step in 1..=len {
+            let idx = (self.selected_index + step) % len;
+            if let Some(problem) = self.containers[idx].problem() {
+                if idx != self.selected_index {
+                    self.selected_index = idx;
+                    self.set_loading();
+                }
+                return Some(problem);
+            }
+        }
+        None
+    }
+
+    // Selects the most recently created running container; returns its ID if the selection moved
+    pub fn select_newest(&mut self) -> Option<String> {
+        let (idx, c) = self.containers
+            .iter()
+            .enumerate()
+            .filter(|(_, c)| c.state == "running")
+            .max_by_key(|(_, c)| c.created.unwrap_or(0))?;
+        if idx == self.selected_index {
+            return None;
+        }
+        let id = c.id.clone();
+        self.selected_index = idx;
+        self.set_loading();
+        Some(id)
+    }
+
+    // Headers and the containers shown under them; just the containers when not grouped
+    fn list_rows(&self) -> Vec<ListRow> {
+        if self.groups.is_empty() {
+            return (0..self.containers.len()).map(ListRow::Container).collect();
+        }
+        let mut rows = Vec::new();
+        for (g, group) in self.groups.iter().enumerate() {
+            rows.push(ListRow::Group(g));
+            if let Some(first) = group.first {
+                rows.extend((first..first + group.total).map(ListRow::Container));
             }
-            self.set_loading();
         }
+        rows
     }
 
+    fn current_row(&self) -> ListRow {
+        match &self.selected_group {
+            Some(name) => ListRow::Group(self.groups.iter().position(|g| &g.name == name).unwrap_or(0)),
+            None => ListRow::Container(self.selected_index),
+        }
+    }
+
+    // Moves the cursor `step` rows (wrapping), headers included
+    fn move_cursor(&mut self, step: isize) {
+        self.follow_newest = false;
+        let rows = self.list_rows();
+        if rows.is_empty() {
+            return;
+        }
+        let pos = rows.iter().position(|r| *r == self.current_row()).unwrap_or(0) as isize;
+        let row = rows[(pos + step).rem_euclid(rows.len() as isize) as usize];
+        self.set_loading();
+        match row {
+            ListRow::Group(g) => {
+                self.selected_group = Some(self.groups[g].name.clone());
+                // Nothing will be fetched for a header
+                self.is_loading_details = false;
+            }
+            ListRow::Container(idx) => self.selected_index = idx,
+        }
+    }
+
+    pub fn next(&mut self) {
+        self.move_cursor(1);
+    }
+
+    pub fn previous(&mut self) {
+        self.move_cursor(-1);
+    }
+
+    // Also takes the cursor off a group header; whatever moved it sets the new position
     fn set_loading(&mut self) {
+        self.selected_group = None;
         self.current_stats = None;
         self.previous_stats = None;
         self.current_inspection = None;
         self.logs.clear();
+        self.log_anchor = None;
+        self.log_scroll = 0;
+        self.env_scroll = 0;
+        self.log_status = LogStatus::Loading;
+        self.watching_file = None;
+        self.clear_history();
+        self.is_loading_details = true;
+    }
+
+    // Charts and sparkline start over, for another container or another run of the same one
+    fn clear_history(&mut self) {
+        self.history_run = None;
         self.cpu_history.clear();
+        self.container_cpu_history.clear();
         self.net_rx_history.clear();
         self.net_tx_history.clear();
         self.x_axis_bounds = [0.0, 100.0];
         self.net_axis_bounds = [0.0, 100.0];
-        self.is_loading_details = true;
+    }
+
+    // Called with each inspect of the selected container. A restart keeps the ID but starts a
+    // new run whose counters begin at zero, so the history (and the previous sample the next
+    // CPU delta would be taken against) belongs to the old run and is dropped.
+    pub fn note_run(&mut self, started_at: Option<&str>) {
+        let started_at = match started_at {
+            Some(s) => s,
+            None => return,
+        };
+        if self.history_run.as_deref().map(|run| run != started_at).unwrap_or(false) {
+            self.clear_history();
+            self.previous_stats = None;
+        }
+        self.history_run = Some(started_at.to_string());
     }
 
     pub fn get_selected_container(&self) -> Option<&Container> {
+        if self.selected_group.is_some() {
+            return None;
+        }
         self.containers.get(self.selected_index)
     }
 
-    pub fn add_log(&mut self, log: String) {
+    // Masked env of the inspected container, narrowed by env_filter. Matching runs on the masked
+    // values so a query can't be used to probe a hidden secret.
+    pub fn filtered_env(&self) -> Vec<(String, String)> {
+        let query = self.env_filter.to_lowercase();
+        self.current_inspection
+            .as_ref()
+            .and_then(|i| i.config.as_ref())
+            .and_then(|c| c.env.as_ref())
+            .map(|env| {
+                env.iter()
+                    .map(|e| match e.split_once('=') {
+                        Some((k, v)) if self.reveal_env => (k.to_string(), v.to_string()),
+                        _ if self.reveal_env => (e.clone(), String::new()),
+                        _ => crate::docker::masked_env(e),
+                    })
+                    .filter(|(k, v)| query.is_empty() || k.to_lowercase().contains(&query) || v.to_lowercase().contains(&query))
+                    .collect()
+            })
+            .unwrap_or_default()
+    }
+
+    // Daemon endpoint worth showing in the header: the fixture in mock mode, or any host but the default socket
+    pub fn host_label(&self) -> Option<String> {
+        if let Some(path) = &self.mock_source {
+            return Some(format!("mock: {}", path));
+        }
+        match crate::docker::daemon_host() {
+            crate::docker::DaemonHost::Unix(path) if path == crate::docker::DEFAULT_SOCKET => None,
+            host => Some(host.label()),
+        }
+    }
+
+    // Strength of the "just changed" highlight: 2 when fresh, 1 while fading, 0 once it's gone
+    pub fn recent_change_level(&self, id: &str) -> u8 {
+        match self.state_changed.get(id).map(|t| t.elapsed()) {
+            Some(e) if e < RECENT_CHANGE_HIGHLIGHT / 2 => 2,
+            Some(e) if e < RECENT_CHANGE_HIGHLIGHT => 1,
+            _ => 0,
+        }
+    }
+
+    // Exited, created or dead: there is nothing to follow, only a log tail to read
+    pub fn is_stopped(state: &str) -> bool {
+        matches!(state, "exited" | "created" | "dead")
+    }
+
+    // Paused containers keep reporting their last pre-pause stats, so CPU figures for them are stale
+    pub fn is_paused(&self, id: &str) -> bool {
+        self.containers.iter().any(|c| c.id == id && c.state == "paused")
+    }
+
+    // Next step of the log refresh cycle; a configured value off the steps moves to the next larger one
+    pub fn cycle_log_refresh(&mut self) -> u64 {
+        self.log_refresh_secs = LOG_REFRESH_STEPS
+            .iter()
+            .copied()
+            .find(|s| *s > self.log_refresh_secs)
+            .unwrap_or(LOG_REFRESH_STEPS[0]);
+        self.log_refresh_secs
+    }
+
+    pub fn stats_interval_label(&self) -> String {
+        format_interval(self.stats_interval)
+    }
+
+    pub fn log_refresh_label(&self) -> String {
+        match self.log_refresh_secs {
+            0 => "off".to_string(),
+            s => format!("{}s", s),
+        }
+    }
+
+    // Clears the panel ahead of the streamer reconnecting to a different source
+    pub fn reset_logs(&mut self) {
+        self.logs.clear();
+        self.log_anchor = None;
+        self.log_scroll = 0;
+        self.log_status = LogStatus::Loading;
+    }
+
+    pub fn add_log(&mut self, log: (bool, String)) {
         if self.logs.len() >= 100 {
             self.logs.pop_front();
+            // Keep the anchor on the same line as the window slides
+            self.log_anchor = self.log_anchor.map(|i| i.saturating_sub(1));
+            self.log_scroll = self.log_scroll.saturating_sub(1);
         }
         self.logs.push_back(log);
     }
 
+    // Case-insensitive (ASCII) so the highlighted ranges line up byte for byte with the original
+    pub fn log_line_matches(&self, line: &str) -> bool {
+        !self.log_search.is_empty() && line.to_ascii_lowercase().contains(&self.log_search.to_ascii_lowercase())
+    }
+
+    pub fn log_match_count(&self) -> usize {
+        self.logs.iter().filter(|(_, l)| self.log_line_matches(l)).count()
+    }
+
+    pub fn set_log_search(&mut self, query: String) {
+        self.log_search = query;
+        self.log_anchor = None;
+        if !self.log_search.is_empty() {
+            // Start from the newest hit, which is usually the one being looked for
+            self.step_log_match(false);
+        }
+    }
+
+    // Moves the anchor to the next (or previous) matching line, wrapping around, and stops
+    // following so the hit stays on screen. From the live tail, "next" wraps to the oldest hit.
+    pub fn step_log_match(&mut self, forward: bool) -> bool {
+        let hits: Vec<usize> = (0..self.logs.len()).filter(|&i| self.log_line_matches(&self.logs[i].1)).collect();
+        let target = match (self.log_anchor, forward) {
+            (Some(a), true) => hits.iter().find(|&&i| i > a).or(hits.first()),
+            (Some(a), false) => hits.iter().rev().find(|&&i| i < a).or(hits.last()),
+            (None, true) => hits.first(),
+            (None, false) => hits.last(),
+        };
+        match target {
+            Some(&i) => {
+                self.log_anchor = Some(i);
+                // A little context above the hit
+                self.log_scroll = i.saturating_sub(2);
+                self.log_follow = false;
+                true
+            }
+            None => {
+                self.set_action_status(format!("No log lines match \"{}\"", self.log_search));
+                false
+            }
+        }
+    }
+
+    // Marks or unmarks the selected container; marking a third drops the oldest mark
+    pub fn toggle_mark(&mut self) {
+        let id = match self.get_selected_container() {
+            Some(c) => c.id.clone(),
+            None => return,
+        };
+        if let Some(pos) = self.marked.iter().position(|m| *m == id) {
+            self.marked.remove(pos);
+        } else {
+            if self.marked.len() >= 2 {
+                self.marked.remove(0);
+            }
+            self.marked.push(id);
+        }
+    }
+
+    // Folds away the group the cursor is in (or on the header of); a cursor inside moves on to the next shown container
+    pub fn collapse_selected_group(&mut self) {
+        if !self.group_by_project {
+            return;
+        }
+        let name = match (&self.selected_group, self.get_selected_container()) {
+            (Some(name), _) => name.clone(),
+            (None, Some(c)) => c.compose_project().unwrap_or(UNGROUPED).to_string(),
+            (None, None) => return,
+        };
+        self.collapsed_groups.insert(name);
+        self.refilter();
+    }
+
+    // Adds the selected container to the batch, or takes it out again
+    pub fn toggle_batch(&mut self) {
+        let id = match self.get_selected_container() {
+            Some(c) => c.id.clone(),
+            None => return,
+        };
+        match self.batch.iter().position(|b| *b == id) {
+            Some(pos) => { self.batch.remove(pos); }
+            None => self.batch.push(id),
+        }
+    }
+
+    // Like request_action, for one action per batched container (filtered out or not): a single
+    // confirm covers them all, and they are sent together as one Action::Batch
+    pub fn request_batch(&mut self, make: fn(String) -> crate::action::Action) -> Option<crate::action::Action> {
+        let ids = self.batch.clone();
+        self.request_many(ids, make)
+    }
+
+    // The same for every container of the Compose project whose header the cursor is on, hidden
+    // by the filter or a folded group or not
+    pub fn request_project(&mut self, make: fn(String) -> crate::action::Action) -> Option<crate::action::Action> {
+        let project = match self.selected_group.as_deref() {
+            Some(name) if name != UNGROUPED => name.to_string(),
+            _ => {
+                self.set_action_status("Not a Compose project".to_string());
+                return None;
+            }
+        };
+        let ids = self.all_containers.iter().filter(|c| c.compose_project() == Some(project.as_str())).map(|c| c.id.clone()).collect();
+        self.request_many(ids, make)
+    }
+
+    fn request_many(&mut self, ids: Vec<String>, make: fn(String) -> crate::action::Action) -> Option<crate::action::Action> {
+        let actions: Vec<crate::action::Action> = ids.into_iter().map(make).collect();
+        if actions.is_empty() || self.refuses_in_mock(&actions[0]) {
+            return None;
+        }
+        if actions.iter().any(|a| self.needs_confirm(a)) {
+            let verb = match &actions[0] {
+                crate::action::Action::Start(_) => "Start",
+                crate::action::Action::Stop(_) => "Stop",
+                crate::action::Action::Restart(_) => "Restart",
+                _ => "Remove",
+            };
+            let names: Vec<String> = actions
+                .iter()
+                .filter_map(|a| a.lifecycle_target())
+                .map(|(id, _)| self.all_containers.iter().find(|c| c.id == id).map(|c| self.container_label(c)).unwrap_or_else(|| self.id_text(id)))
+                .collect();
+            let warning = if verb != "Start" && actions.iter().filter_map(|a| a.lifecycle_target()).any(|(id, _)| self.is_self(id)) {
+                "\n\nOne of them is the container docktop is running in: docktop will exit with it."
+            } else {
+                ""
+            };
+            self.ask_confirm(
+                format!(" {} {} containers ", verb, names.len()),
+                format!("{} {}?{}\n\n(y/N)", verb, names.join(", "), warning),
+                crate::action::Action::Batch(actions),
+            );
+            return None;
+        }
+        self.queue_action(crate::action::Action::Batch(actions))
+    }
+
+    pub fn is_self(&self, id: &str) -> bool {
+        self.self_container.as_deref().map(|own| !own.is_empty() && id.starts_with(own)).unwrap_or(false)
+    }
+
+    // How a container is labelled wherever one is named: its names, or its short ID with show_ids
+    pub fn container_label(&self, c: &crate::docker::Container) -> String {
+        if self.show_ids {
+            c.id.chars().take(12).collect()
+        } else {
+            c.names.iter().map(|n| n.trim_start_matches('/')).collect::<Vec<_>>().join(", ")
+        }
+    }
+
+    // IDs are shortened to docker's 12 characters, unless IDs are what the user works with
+    pub fn id_text(&self, id: &str) -> String {
+        if self.show_ids {
+            id.to_string()
+        } else {
+            id.chars().take(12).collect()
+        }
+    }
+
+    // 0 = cool, 1 = warm, 2 = hot. Absolute mode uses fixed thresholds; relative mode ranks
+    // the container against the latest samples of all others, so the busiest is always hot.
+    pub fn cpu_heat(&self, cpu: f64) -> u8 {
+        if !self.relative_cpu_colors {
+            return heat(cpu);
+        }
+        let busier = self.strip_stats.values().filter(|(_, c)| *c > cpu).count();
+        let total = self.strip_stats.len();
+        if cpu < 0.5 {
+            0 // Idle containers stay cool even if everything is idle
+        } else if busier == 0 {
+            2
+        } else if busier < total / 2 {
+            1
+        } else {
+            0
+        }
+    }
+
+    pub fn update_strip_stats(&mut self, id: String, stats: Option<ContainerStats>) {
+        let previous = self.strip_stats.remove(&id).map(|(s, _)| s);
+        let stats = match stats {
+            Some(s) => s,
+            None => {
+                self.strip_missing.insert(id);
+                return;
+            }
+        };
+        self.strip_missing.remove(&id);
+        let cpu = crate::ui::calculate_cpu_usage(&stats, &previous);
+        self.strip_stats.insert(id, (stats, cpu));
+    }
+
+    pub fn add_event(&mut self, event: DockerEvent) {
+        if self.events.len() >= 500 {
+            self.events.pop_front();
+        }
+        self.events.push_back(event);
+    }
+
+    pub fn filtered_events(&self) -> Vec<&DockerEvent> {
+        let filter = self.events_view.as_ref().map(|v| EVENT_TYPES[v.filter]).unwrap_or("all");
+        self.events
+            .iter()
+            .filter(|e| filter == "all" || e.type_ == filter)
+            .collect()
+    }
+
+    // Whether a lifecycle action asks first, per confirm_actions: "none", "destructive-only"
+    // (remove and kill, plus restart with confirm_on_restart; the older confirm_on_delete switch in
+    // Settings can still turn remove off, confirm_on_kill does the same for kill) or "all"
+    pub fn needs_confirm(&self, action: &crate::action::Action) -> bool {
+        // Stopping our own container takes the UI down with it, whatever the policy says
+        if let Some((id, verb)) = action.lifecycle_target() {
+            if verb != "started" && self.is_self(id) {
+                return true;
+            }
+        }
+        let general = &self.config.general;
+        match general.confirm_actions.as_str() {
+            "none" => false,
+            "all" => action.is_lifecycle(),
+            _ => match action {
+                crate::action::Action::Delete(_) => general.confirm_on_delete,
+                crate::action::Action::Restart(_) => general.confirm_on_restart,
+                crate::action::Action::Kill { .. } => general.confirm_on_kill,
+                _ => false,
+            },
+        }
+    }
+
+    // Entry point for lifecycle keys: opens the confirm overlay when the policy wants it,
+    // otherwise queues. Returns the action to send now, if any.
+    pub fn request_action(&mut self, action: crate::action::Action) -> Option<crate::action::Action> {
+        if self.refuses_in_mock(&action) {
+            return None;
+        }
+        if !self.needs_confirm(&action) {
+            return self.queue_action(action);
+        }
+        let (id, verb) = match &action {
+            crate::action::Action::Start(id) => (id, "Start"),
+            crate::action::Action::Stop(id) => (id, "Stop"),
+            crate::action::Action::Kill { id, .. } => (id, "Kill"),
+            crate::action::Action::Restart(id) => (id, "Restart"),
+            crate::action::Action::Delete(id) => (id, "Remove"),
+            _ => return self.queue_action(action),
+        };
+        let name = self.containers.iter().find(|c| &c.id == id).map(|c| self.container_label(c)).unwrap_or_else(|| self.id_text(id));
+        let warning = if self.is_self(id) && verb != "Start" {
+            "\n\nThis is the container docktop is running in: docktop will exit with it."
+        } else {
+            ""
+        };
+        self.ask_confirm(format!(" {} container ", verb), format!("{} {}?{}\n\n(y/N)", verb, name, warning), action);
+        None
+    }
+
+    // Lifecycle actions would need a real daemon; turn them away before they are confirmed or queued,
+    // so a reply the queue doesn't wait for can't leave it stuck
+    fn refuses_in_mock(&mut self, action: &crate::action::Action) -> bool {
+        if self.mock_source.is_none() || !action.is_lifecycle() {
+            return false;
+        }
+        self.set_action_status("Container actions are not available in mock mode".to_string());
+        true
+    }
+
+    // Opens the y/N overlay; `action` is sent (through the queue for lifecycle actions) on yes and dropped otherwise
+    pub fn ask_confirm(&mut self, title: String, message: String, action: crate::action::Action) {
+        self.confirm = Some(Confirm { title, message, action });
+    }
+
+    // Start/stop/restart/remove run one at a time, each reporting before the next is sent, so a
+    // quick stop+start can't interleave. Returns the action to send now, if nothing is in flight.
+    pub fn queue_action(&mut self, action: crate::action::Action) -> Option<crate::action::Action> {
+        if self.refuses_in_mock(&action) {
+            return None;
+        }
+        if self.action_in_flight {
+            self.action_queue.push_back(action);
+            self.set_action_status(format!("Queued ({} waiting)", self.action_queue.len()));
+            return None;
+        }
+        self.action_in_flight = true;
+        self.queue_results.clear();
+        self.in_flight = action.lifecycle_targets();
+        Some(action)
+    }
+
+    // Whether `done` is the result of the action the queue is waiting on. Other results (the wizard's
+    // create, an image removal) share the channel and mustn't release the next queued action.
+    pub fn finishes_in_flight(&self, done: &crate::action::ActionDone) -> bool {
+        self.action_in_flight && !done.targets.is_empty() && done.targets == self.in_flight
+    }
+
+    // Records a lifecycle result (one per container for a batch) and hands back the next queued action, if any
+    pub fn finish_queued_action(&mut self, done: &crate::action::ActionDone) -> Option<crate::action::Action> {
+        self.queue_results.extend(done.results.iter().cloned());
+        for ((id, verb), result) in done.targets.iter().zip(&done.results) {
+            let ok = !result.starts_with("Failed");
+            self.last_actions.insert(id.clone(), LastAction { verb, ok, at: std::time::Instant::now() });
+        }
+        let next = self.action_queue.pop_front();
+        self.action_in_flight = next.is_some();
+        self.in_flight = next.as_ref().map(|a| a.lifecycle_targets()).unwrap_or_default();
+        next
+    }
+
+    // A single result as is; a longer run as counts per outcome ("Stopped 3, failed 1")
+    // followed by each failure in full
+    pub fn queue_summary(&self) -> String {
+        if self.queue_results.len() < 2 {
+            return self.queue_results.join("\n");
+        }
+        let mut counts: Vec<(&str, usize)> = Vec::new();
+        for result in &self.queue_results {
+            let word = if result.starts_with("Failed") { "failed" } else { result.split(' ').next().unwrap_or("") };
+            match counts.iter_mut().find(|(w, _)| *w == word) {
+                Some((_, n)) => *n += 1,
+                None => counts.push((word, 1)),
+            }
+        }
+        // Failures count last, whatever order they came in
+        counts.sort_by_key(|(w, _)| *w == "failed");
+        let mut lines = vec![counts.iter().map(|(w, n)| format!("{} {}", w, n)).collect::<Vec<_>>().join(", ")];
+        lines.extend(self.queue_results.iter().filter(|r| r.starts_with("Failed")).cloned());
+        lines.join("\n")
+    }
+
     pub fn set_action_status(&mut self, msg: String) {
+        // Results arrive as "Failed to <operation>: <daemon error>"; keep the whole thing
+        for line in msg.lines().filter(|l| is_error_message(l)) {
+            match line.split_once(": ") {
+                Some((operation, detail)) => self.record_error(operation, detail),
+                None => self.record_error(line, line),
+            }
+        }
         self.action_status = Some((msg, std::time::Instant::now()));
     }
 
+    pub fn record_error(&mut self, operation: &str, message: &str) {
+        self.last_error = Some(LastError {
+            operation: operation.to_string(),
+            message: message.to_string(),
+            at: chrono::Local::now(),
+        });
+    }
+
+    // The timestamp belongs to the message, so replacing it can't get the new one cleared early
     pub fn clear_action_status(&mut self) {
         if let Some((_, time)) = self.action_status {
-            if time.elapsed() > std::time::Duration::from_secs(3) {
+            if time.elapsed() >= STATUS_TTL {
                 self.action_status = None;
             }
         }
@@ -212,6 +1356,14 @@ impl App {
         }
     }
 
+    pub fn update_container_cpu_history(&mut self, cpu: f64) {
+        self.container_cpu_history.push(cpu);
+        let limit = self.config.general.graphs_history_size;
+        if self.container_cpu_history.len() > limit {
+            self.container_cpu_history.remove(0);
+        }
+    }
+
     pub fn update_net_history(&mut self, rx: f64, tx: f64) {
         let x = if let Some(last) = self.net_rx_history.last() {
             last.0 + 1.0
@@ -263,6 +1415,67 @@ impl App {
         self.update_net_history(total_rx / 1024.0, total_tx / 1024.0);
     }
 
+    // Records each container's restart count when it changes, keeping the samples the crash-loop window needs
+    pub fn update_restart_counts(&mut self, counts: std::collections::HashMap<String, u64>) {
+        let now = std::time::Instant::now();
+        self.restart_samples.retain(|id, _| counts.contains_key(id));
+        for (id, count) in counts {
+            let samples = self.restart_samples.entry(id).or_default();
+            if samples.last().map(|(_, c)| *c != count).unwrap_or(true) {
+                samples.push((now, count));
+            }
+            // Keep one sample from before the window as the baseline
+            while samples.len() > 1 && now.duration_since(samples[1].0) > CRASH_LOOP_WINDOW {
+                samples.remove(0);
+            }
+        }
+    }
+
+    // Restarts within the last CRASH_LOOP_WINDOW, when there are enough to call it a crash loop
+    pub fn crash_loop(&self, id: &str) -> Option<u64> {
+        let samples = self.restart_samples.get(id)?;
+        let (first, last) = (samples.first()?, samples.last()?);
+        let restarts = last.1.saturating_sub(first.1);
+        if last.0.elapsed() <= CRASH_LOOP_WINDOW && restarts >= CRASH_LOOP_RESTARTS { Some(restarts) } else { None }
+    }
+
+    pub fn crash_loop_alert(&self) -> Option<String> {
+        let alerts: Vec<String> = self.containers
+            .iter()
+            .filter_map(|c| {
+                let restarts = self.crash_loop(&c.id)?;
+                let name = c.names.first().map(|n| n.trim_start_matches('/')).unwrap_or("");
+                Some(format!("{} ({} restarts in last minute)", name, restarts))
+            })
+            .collect();
+        if alerts.is_empty() { None } else { Some(alerts.join(", ")) }
+    }
+
+    // Alert for the running containers together, shown in the footer once a configured threshold is
+    // crossed. Other processes on the host don't count. Both totals are shares of the host: the
+    // containers' CPU % (100% per core, as `docker stats`) over the cores, their memory over the RAM.
+    pub fn resource_alert(&self) -> Option<String> {
+        let general = &self.config.general;
+        let mut alerts = Vec::new();
+
+        let cores = self._system.cpus().len().max(1) as f64;
+        let cpu_usage = self.strip_stats.values().map(|(_, cpu)| *cpu).sum::<f64>() / cores;
+        if general.alert_cpu_percent > 0.0 && cpu_usage >= general.alert_cpu_percent {
+            alerts.push(format!("Containers CPU {:.0}% >= {:.0}%", cpu_usage, general.alert_cpu_percent));
+        }
+
+        let total_mem = self._system.total_memory();
+        if general.alert_memory_percent > 0.0 && total_mem > 0 {
+            let used: u64 = self.strip_stats.values().filter_map(|(s, _)| s.memory_usage()).sum();
+            let mem_usage = used as f64 / total_mem as f64 * 100.0;
+            if mem_usage >= general.alert_memory_percent {
+                alerts.push(format!("Containers MEM {:.0}% >= {:.0}%", mem_usage, general.alert_memory_percent));
+            }
+        }
+
+        if alerts.is_empty() { None } else { Some(alerts.join(" | ")) }
+    }
+
     pub fn update_fish(&mut self) {
         for fish in &mut self.fishes {
             fish.x += fish.direction * fish.speed;
@@ -274,6 +1487,10 @@ impl App {
         }
     }
 
+    pub fn update_spinner(&mut self) {
+        self.spinner_frame = self.spinner_frame.wrapping_add(1);
+    }
+
     pub fn update_wizard_spinner(&mut self) {
         if let Some(wizard) = &mut self.wizard {
             if let WizardStep::Processing { spinner_frame, .. } = &mut wizard.step {
@@ -1230,3 +2447,127 @@ impl App {
         wizard_action
     }
 }
+
+// "500ms", "2s" or "1.5s"
+fn format_interval(interval: std::time::Duration) -> String {
+    let ms = interval.as_millis();
+    if ms < 1000 {
+        format!("{}ms", ms)
+    } else if ms % 1000 == 0 {
+        format!("{}s", ms / 1000)
+    } else {
+        format!("{:.1}s", interval.as_secs_f64())
+    }
+}
+
+// Fixed thresholds for a percentage: 0 below 50%, 1 below 80%, 2 from there up
+pub fn heat(percent: f64) -> u8 {
+    if percent >= 80.0 { 2 } else if percent >= 50.0 { 1 } else { 0 }
+}
+
+fn is_error_message(msg: &str) -> bool {
+    let lower = msg.to_lowercase();
+    lower.starts_with("failed") || lower.starts_with("error") || lower.starts_with("invalid") || lower.contains(" failed")
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use crate::action::{Action, ActionDone};
+
+    fn done(id: &str, verb: &'static str, message: &str) -> ActionDone {
+        ActionDone { targets: vec![(id.to_string(), verb)], results: vec![message.to_string()], notify: true, message: message.to_string() }
+    }
+
+    #[test]
+    fn quick_stop_then_start_runs_in_order() {
+        let mut app = App::new();
+        assert!(matches!(app.queue_action(Action::Stop("abc".to_string())), Some(Action::Stop(_))));
+        assert!(app.queue_action(Action::Start("abc".to_string())).is_none());
+
+        // The start waits until the stop has reported back
+        let stopped = done("abc", "stopped", "Stopped container abc");
+        assert!(app.finishes_in_flight(&stopped));
+        assert!(matches!(app.finish_queued_action(&stopped), Some(Action::Start(_))));
+        assert!(app.action_in_flight);
+
+        let started = done("abc", "started", "Started container abc");
+        assert!(app.finishes_in_flight(&started));
+        assert!(app.finish_queued_action(&started).is_none());
+        assert!(!app.action_in_flight);
+        assert_eq!(app.queue_summary(), "Stopped 1, Started 1");
+    }
+
+    #[test]
+    fn unrelated_results_leave_the_queue_waiting() {
+        let mut app = App::new();
+        app.queue_action(Action::Stop("abc".to_string()));
+        app.queue_action(Action::Start("def".to_string()));
+
+        // The wizard's create reports "Failed to start" too, but isn't what the queue sent
+        let message = "Failed to start: port is already allocated".to_string();
+        let create = ActionDone { targets: Vec::new(), results: vec![message.clone()], notify: true, message };
+        assert!(!app.finishes_in_flight(&create));
+        // Nor is a result for another container or verb
+        assert!(!app.finishes_in_flight(&done("def", "started", "Started container def")));
+        assert!(!app.finishes_in_flight(&done("abc", "started", "Started container abc")));
+        assert!(app.finishes_in_flight(&done("abc", "stopped", "Stopped container abc")));
+    }
+
+    #[test]
+    fn batch_reports_each_container() {
+        let mut app = App::new();
+        let batch = Action::Batch(vec![Action::Stop("abc".to_string()), Action::Stop("def".to_string())]);
+        assert!(matches!(app.queue_action(batch), Some(Action::Batch(_))));
+
+        let results = vec!["Stopped container abc".to_string(), "Failed to stop container def: gone".to_string()];
+        let stopped = ActionDone {
+            targets: vec![("abc".to_string(), "stopped"), ("def".to_string(), "stopped")],
+            results: results.clone(),
+            notify: true,
+            message: results.join("\n"),
+        };
+        assert!(app.finishes_in_flight(&stopped));
+        assert!(app.finish_queued_action(&stopped).is_none());
+        assert!(app.last_actions["abc"].ok);
+        assert!(!app.last_actions["def"].ok);
+        assert_eq!(app.queue_summary(), "Stopped 1, failed 1\nFailed to stop container def: gone");
+    }
+
+    fn container(id: &str, project: Option<&str>) -> Container {
+        let labels = project.map(|p| serde_json::json!({ "com.docker.compose.project": p }));
+        serde_json::from_value(serde_json::json!({
+            "Id": id, "Names": [format!("/{}", id)], "Image": "alpine", "State": "exited", "Status": "Exited (0)", "Labels": labels,
+        }))
+        .unwrap()
+    }
+
+    #[test]
+    fn project_header_acts_on_every_member() {
+        let mut app = App::new();
+        app.group_by_project = true;
+        app.update_containers(vec![container("db", Some("shop")), container("web", Some("shop")), container("tool", None)]);
+
+        // Up from the first member lands on its project's header, which selects no container
+        app.previous();
+        assert_eq!(app.selected_group.as_deref(), Some("shop"));
+        assert!(app.get_selected_container().is_none());
+
+        // Folded members count too
+        app.collapsed_groups.insert("shop".to_string());
+        app.refilter();
+        match app.request_project(Action::Start) {
+            Some(batch @ Action::Batch(_)) => {
+                let ids: Vec<String> = batch.lifecycle_targets().into_iter().map(|(id, _)| id).collect();
+                assert_eq!(ids, ["db", "web"]);
+            }
+            other => panic!("expected a batch, got {:?}", other),
+        }
+
+        // Down from the folded header skips to the next one
+        app.next();
+        assert_eq!(app.selected_group.as_deref(), Some(UNGROUPED));
+        app.next();
+        assert_eq!(app.get_selected_container().map(|c| c.id.as_str()), Some("tool"));
+    }
+}
@@ -0,0 +1,38 @@
+use std::io::{self, Write};
+
+// Most terminals silently drop OSC52 payloads much larger than this (base64-encoded size)
+pub const OSC52_MAX_ENCODED: usize = 100_000;
+
+const BASE64_CHARS: &[u8; 64] = b"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/";
+
+pub fn base64_encode(data: &[u8]) -> String {
+    let mut out = String::with_capacity((data.len() + 2) / 3 * 4);
+    for chunk in data.chunks(3) {
+        let b = [chunk[0], *chunk.get(1).unwrap_or(&0), *chunk.get(2).unwrap_or(&0)];
+        let n = ((b[0] as u32) << 16) | ((b[1] as u32) << 8) | b[2] as u32;
+        out.push(BASE64_CHARS[(n >> 18) as usize & 63] as char);
+        out.push(BASE64_CHARS[(n >> 12) as usize & 63] as char);
+        out.push(if chunk.len() > 1 { BASE64_CHARS[(n >> 6) as usize & 63] as char } else { '=' });
+        out.push(if chunk.len() > 2 { BASE64_CHARS[n as usize & 63] as char } else { '=' });
+    }
+    out
+}
+
+pub enum CopyResult {
+    Clipboard,
+    File(String),
+}
+
+// Copies via the terminal's OSC52 escape, or writes to a temp file when the payload is too large
+pub fn copy_or_save(text: &str, fallback_path: &str) -> io::Result<CopyResult> {
+    let encoded = base64_encode(text.as_bytes());
+    if encoded.len() > OSC52_MAX_ENCODED {
+        std::fs::write(fallback_path, text)?;
+        return Ok(CopyResult::File(fallback_path.to_string()));
+    }
+
+    let mut stdout = io::stdout();
+    write!(stdout, "\x1b]52;c;{}\x07", encoded)?;
+    stdout.flush()?;
+    Ok(CopyResult::Clipboard)
+}
@@ -20,6 +20,7 @@ pub struct Config {
 }
 
 #[derive(Debug, Deserialize, Serialize, Clone)]
+#[serde(default)]
 pub struct KeyConfig {
     pub quit: String,
     pub refresh: String,
@@ -37,6 +38,53 @@ pub struct KeyConfig {
     pub stop: String,
     pub start: String,
     pub yaml: String,
+    pub next_match: String,
+    pub prev_match: String,
+    pub exec_command: String,
+    pub watch_file: String,
+    pub toggle_line_numbers: String,
+    pub toggle_log_wrap: String,
+    pub search_logs: String,
+    pub copy_inspect: String,
+    pub inspect: String,
+    pub toggle_events: String,
+    pub export: String,
+    pub save_image: String,
+    pub load_image: String,
+    pub toggle_log_mode: String,
+    pub restart_follow: String,
+    pub toggle_log_follow: String,
+    pub mark: String,
+    pub batch_select: String,
+    pub prune: String,
+    pub toggle_groups: String,
+    pub collapse_group: String,
+    pub expand_groups: String,
+    pub rename: String,
+    pub compare: String,
+    pub toggle_stats_strip: String,
+    pub copy_exec: String,
+    pub copy_id: String,
+    pub copy_logs: String,
+    pub filter_env: String,
+    pub toggle_env: String,
+    pub reveal_env: String,
+    pub shrink_list: String,
+    pub grow_list: String,
+    pub shrink_logs: String,
+    pub grow_logs: String,
+    pub next_problem: String,
+    pub dump_stats: String,
+    pub save_logs: String,
+    pub toggle_cpu_colors: String,
+    pub cycle_log_refresh: String,
+    pub show_last_error: String,
+    pub toggle_ids: String,
+    pub toggle_zen: String,
+    pub cycle_sort: String,
+    pub toggle_pause: String,
+    pub toggle_images: String,
+    pub kill: String,
 }
 
 impl Default for KeyConfig {
@@ -58,11 +106,59 @@ impl Default for KeyConfig {
             stop: "s".to_string(),
             start: "v".to_string(),
             yaml: "y".to_string(),
+            next_match: "n".to_string(),
+            prev_match: "N".to_string(),
+            exec_command: ":".to_string(),
+            watch_file: "f".to_string(),
+            toggle_line_numbers: "#".to_string(),
+            toggle_log_wrap: "W".to_string(),
+            search_logs: "l".to_string(),
+            copy_inspect: "C".to_string(),
+            inspect: "J".to_string(),
+            toggle_events: "D".to_string(),
+            export: "T".to_string(),
+            save_image: "I".to_string(),
+            load_image: "L".to_string(),
+            toggle_log_mode: "P".to_string(),
+            restart_follow: "R".to_string(),
+            toggle_log_follow: "F".to_string(),
+            mark: "m".to_string(),
+            batch_select: "u".to_string(),
+            prune: "g".to_string(),
+            toggle_groups: "G".to_string(),
+            collapse_group: "H".to_string(),
+            expand_groups: "U".to_string(),
+            rename: "F2".to_string(),
+            compare: "=".to_string(),
+            toggle_stats_strip: "b".to_string(),
+            copy_exec: "S".to_string(),
+            copy_id: "Y".to_string(),
+            copy_logs: "t".to_string(),
+            filter_env: "V".to_string(),
+            toggle_env: "p".to_string(),
+            reveal_env: "h".to_string(),
+            shrink_list: "<".to_string(),
+            grow_list: ">".to_string(),
+            shrink_logs: "-".to_string(),
+            grow_logs: "+".to_string(),
+            next_problem: "!".to_string(),
+            dump_stats: "Z".to_string(),
+            save_logs: "O".to_string(),
+            toggle_cpu_colors: "%".to_string(),
+            cycle_log_refresh: "a".to_string(),
+            show_last_error: "X".to_string(),
+            toggle_ids: "i".to_string(),
+            toggle_zen: "z".to_string(),
+            cycle_sort: "o".to_string(),
+            toggle_pause: "space".to_string(),
+            toggle_images: "2".to_string(),
+            kill: "K".to_string(),
         }
     }
 }
 
 #[derive(Debug, Deserialize, Serialize, Clone)]
+#[serde(default)]
 pub struct GeneralConfig {
     pub theme: String,
     pub refresh_rate_ms: u64,
@@ -70,12 +166,40 @@ pub struct GeneralConfig {
     pub show_braille: bool,
     pub confirm_on_delete: bool,
     pub confirm_on_restart: bool,
+    pub confirm_on_kill: bool,
+    pub confirm_actions: String, // "none", "destructive-only" (remove, kill) or "all" (start/stop/restart/kill/remove)
     pub log_tail_lines: usize,
     pub default_sort: String,
+    pub default_filter: String,
+    pub default_scope: String, // "all", "running" or "stopped"
+    pub default_panel: String, // "tools", "details" or "events"
     pub show_all_containers: bool,
+    pub list_stopped_containers: bool, // false = only running containers are requested from the daemon
+    pub list_container_sizes: bool, // Ask for layer sizes with every list (slow); implied by default_sort = "disk"
     pub docker_cli_path: String,
     pub graphs_history_size: usize,
-    pub enable_notifications: bool,
+    pub enable_notifications: bool, // Desktop notification (notify-send / osascript) when an action finishes
+    pub action_bell: bool, // Terminal bell when an action finishes
+    pub filter_mode: String, // "hide" or "highlight"
+    pub alert_cpu_percent: f64, // 0 disables the alert
+    pub alert_memory_percent: f64,
+    pub log_line_numbers: bool,
+    pub log_wrap: bool, // Wrap long log lines onto extra rows; false cuts them at the panel edge
+    pub log_mode: String, // "stream" or "poll"
+    pub log_refresh_secs: u64, // Re-fetch interval for polled and stopped-container logs (0 = off)
+    pub stats_interval_ms: u64, // How often stats are fetched for details, compare and the strip (min 500)
+    pub symbols: String, // "auto", "unicode", "ascii" or "nerd"
+    pub stats_strip: bool, // CPU strip for all running containers above the footer
+    pub show_container_ids: bool, // Identify containers by ID instead of name (list column, details, compare)
+    pub group_by_project: bool, // List containers under their Compose project
+    pub exec_shell: String, // Shell used in the `docker exec` command copied by copy_exec
+    pub memory_units: String, // "auto", "mb" or "gb"
+    pub memory_base: String, // "binary" (MiB/GiB, as `docker stats`) or "decimal" (MB/GB)
+    pub layout: String, // "default", "logs" or "list"
+    pub cpu_colors: String, // "absolute" (fixed thresholds) or "relative" (ranked against the other containers)
+    pub hidden_panels: Vec<String>, // Any of "monitor", "list", "side", "charts", "logs"
+    pub list_width: u16, // Percent of the width the list takes next to the side panel in the default layout
+    pub logs_height: u16, // Percent of the panel rows the charts/logs row takes in the default layout
 }
 
 impl Default for GeneralConfig {
@@ -87,12 +211,40 @@ impl Default for GeneralConfig {
             show_braille: true,
             confirm_on_delete: true,
             confirm_on_restart: false,
+            confirm_on_kill: true,
+            confirm_actions: "destructive-only".to_string(),
             log_tail_lines: 100,
             default_sort: "status".to_string(),
+            default_filter: String::new(),
+            default_scope: "all".to_string(),
+            default_panel: "tools".to_string(),
             show_all_containers: true,
+            list_stopped_containers: true,
+            list_container_sizes: false,
             docker_cli_path: "/usr/bin/docker".to_string(),
             graphs_history_size: 60,
             enable_notifications: false,
+            action_bell: false,
+            filter_mode: "hide".to_string(),
+            alert_cpu_percent: 90.0,
+            alert_memory_percent: 90.0,
+            log_line_numbers: false,
+            log_wrap: true,
+            log_mode: "stream".to_string(),
+            log_refresh_secs: 2,
+            stats_interval_ms: DEFAULT_STATS_INTERVAL.as_millis() as u64,
+            symbols: "auto".to_string(),
+            stats_strip: false,
+            show_container_ids: false,
+            group_by_project: false,
+            exec_shell: "/bin/sh".to_string(),
+            memory_units: "auto".to_string(),
+            memory_base: "binary".to_string(),
+            layout: "default".to_string(),
+            cpu_colors: "absolute".to_string(),
+            hidden_panels: Vec::new(),
+            list_width: DEFAULT_LIST_WIDTH,
+            logs_height: DEFAULT_LOGS_HEIGHT,
         }
     }
 }
@@ -270,6 +422,132 @@ pub fn parse_hex_color(hex: &str) -> Color {
     }
 }
 
+// Stats polling cadence when neither the config nor `--interval` gives a usable one.
+// Each poll costs the daemon about a second of sampling per container, so don't go below the minimum.
+pub const DEFAULT_STATS_INTERVAL: std::time::Duration = std::time::Duration::from_secs(2);
+pub const MIN_STATS_INTERVAL: std::time::Duration = std::time::Duration::from_millis(500);
+
+// Bounds and step for resizing the list with shrink_list/grow_list; neither panel gets unusably narrow
+pub const DEFAULT_LIST_WIDTH: u16 = 60;
+pub const MIN_LIST_WIDTH: u16 = 20;
+pub const MAX_LIST_WIDTH: u16 = 80;
+pub const PANEL_RESIZE_STEP: u16 = 5;
+
+// Same for the height of the charts/logs row against the list/details row above it. Whatever the
+// percentage, each row keeps MIN_PANEL_ROWS so borders, a title and a few lines always fit.
+pub const DEFAULT_LOGS_HEIGHT: u16 = 30;
+pub const MIN_LOGS_HEIGHT: u16 = 10;
+pub const MAX_LOGS_HEIGHT: u16 = 90;
+pub const MIN_PANEL_ROWS: u16 = 6;
+
+// Things adjusted at runtime that should survive a restart without rewriting the user's
+// config.toml (and its comments). Lives in ~/.config/docktop/state.toml.
+#[derive(Debug, Default, Deserialize, Serialize)]
+pub struct UiState {
+    pub list_width: Option<u16>,
+    pub logs_height: Option<u16>,
+}
+
+impl UiState {
+    fn path() -> Option<std::path::PathBuf> {
+        std::env::var("HOME").ok().map(|home| Path::new(&home).join(".config/docktop/state.toml"))
+    }
+
+    pub fn load() -> Self {
+        Self::path()
+            .and_then(|p| fs::read_to_string(p).ok())
+            .and_then(|c| toml::from_str(&c).ok())
+            .unwrap_or_default()
+    }
+
+    pub fn save(&self) -> std::io::Result<()> {
+        let path = Self::path().ok_or_else(|| std::io::Error::new(std::io::ErrorKind::NotFound, "HOME is not set"))?;
+        if let Some(dir) = path.parent() {
+            fs::create_dir_all(dir)?;
+        }
+        let content = toml::to_string_pretty(self).map_err(|e| std::io::Error::new(std::io::ErrorKind::Other, e))?;
+        fs::write(path, content)
+    }
+}
+
+// Parses "500ms", "3s", "1.5s" or "1m"; a bare number is seconds
+pub fn parse_interval(text: &str) -> Option<std::time::Duration> {
+    let text = text.trim();
+    let (number, scale) = if let Some(n) = text.strip_suffix("ms") {
+        (n, 0.001)
+    } else if let Some(n) = text.strip_suffix('s') {
+        (n, 1.0)
+    } else if let Some(n) = text.strip_suffix('m') {
+        (n, 60.0)
+    } else {
+        (text, 1.0)
+    };
+    let secs = number.trim().parse::<f64>().ok()? * scale;
+    (secs.is_finite() && secs >= 0.0).then(|| std::time::Duration::from_secs_f64(secs))
+}
+
+// Startup overrides from the command line (`--sort`, `--filter`, `--scope`, `--panel`, `--interval`, `--host`, `--tls*`).
+// They win over the config file but are never written back to it.
+#[derive(Debug, Default)]
+pub struct CliOverrides {
+    pub sort: Option<String>,
+    pub filter: Option<String>,
+    pub scope: Option<String>,
+    pub panel: Option<String>,
+    pub all: Option<bool>, // `--all=false` asks the daemon for running containers only
+    pub follow_newest: bool,
+    pub mock: Option<String>, // Fixture file to read instead of the daemon
+    pub interval: Option<String>, // Stats polling interval, e.g. "3s"
+    pub host: Option<String>, // Daemon to talk to, e.g. "tcp://10.0.0.5:2375"; wins over DOCKER_HOST
+    pub tls: bool, // --tls: encrypted but without checking the daemon's certificate, which is refused
+    pub tls_verify: bool, // --tlsverify, as the docker CLI
+    pub tls_cacert: Option<String>,
+    pub tls_cert: Option<String>,
+    pub tls_key: Option<String>,
+}
+
+impl CliOverrides {
+    pub fn parse(args: &[String]) -> Self {
+        let mut overrides = Self::default();
+        let mut iter = args.iter().skip(1);
+        while let Some(arg) = iter.next() {
+            // Accept both `--flag value` and `--flag=value`
+            let (flag, inline) = match arg.split_once('=') {
+                Some((f, v)) => (f, Some(v.to_string())),
+                None => (arg.as_str(), None),
+            };
+            if flag == "--follow-newest" {
+                overrides.follow_newest = true;
+                continue;
+            }
+            if flag == "--tls" || flag == "--tlsverify" {
+                let on = inline.map(|v| v != "false").unwrap_or(true);
+                if flag == "--tls" { overrides.tls = on } else { overrides.tls_verify = on }
+                continue;
+            }
+            if flag == "--all" {
+                overrides.all = Some(inline.map(|v| v != "false").unwrap_or(true));
+                continue;
+            }
+            let slot = match flag {
+                "--sort" => &mut overrides.sort,
+                "--filter" => &mut overrides.filter,
+                "--scope" => &mut overrides.scope,
+                "--panel" => &mut overrides.panel,
+                "--mock" => &mut overrides.mock,
+                "--interval" => &mut overrides.interval,
+                "--host" | "-H" => &mut overrides.host,
+                "--tlscacert" => &mut overrides.tls_cacert,
+                "--tlscert" => &mut overrides.tls_cert,
+                "--tlskey" => &mut overrides.tls_key,
+                _ => continue,
+            };
+            *slot = inline.or_else(|| iter.next().cloned());
+        }
+        overrides
+    }
+}
+
 pub fn load_theme(name: &str) -> Theme {
     if let Ok(home) = std::env::var("HOME") {
         let path = Path::new(&home).join(format!(".config/docktop/themes/{}.toml", name));
@@ -0,0 +1,201 @@
+use anyhow::Result;
+use serde_json::{json, Value};
+
+// Timestamp given to every fixture log line when the logs are asked for with timestamps
+const FIXTURE_LOG_TIME: &str = "2024-01-01T00:00:00.000000000Z";
+
+// File-backed stand-in for the daemon (`--mock <file>`). It answers the same read-only API
+// requests DockerClient sends over the socket, so the rest of the app can't tell the difference.
+//
+// {
+//   "version":    { ...GET /version payload... },                     (optional)
+//   "containers": [ ...GET /containers/json entries... ],
+//   "inspect":    { "<id>": { ...GET /containers/<id>/json... } },    (optional, synthesized otherwise)
+//   "stats":      { "<id>": { "cpu_percent": 12.5, "memory_usage": 1048576, "memory_limit": 4194304,
+//                             "rx_rate": 2048, "tx_rate": 512 } },   (per second)
+//   "logs":       { "<id>": ["line", ...] }
+//   "images":     [ ...GET /images/json entries... ]                 (optional, synthesized otherwise)
+// }
+pub struct Fixture {
+    data: Value,
+    started: std::time::Instant,
+}
+
+impl Fixture {
+    pub fn load(path: &str) -> Result<Self> {
+        let content = std::fs::read_to_string(path)
+            .map_err(|e| anyhow::anyhow!("Cannot read fixture {}: {}", path, e))?;
+        let data: Value = serde_json::from_str(&content)
+            .map_err(|e| anyhow::anyhow!("Invalid fixture {}: {}", path, e))?;
+        if !data["containers"].is_array() {
+            return Err(anyhow::anyhow!("Invalid fixture {}: missing \"containers\" array", path));
+        }
+        Ok(Self { data, started: std::time::Instant::now() })
+    }
+
+    // Body for a raw HTTP request as DockerClient would send it
+    pub fn respond(&self, request: &str) -> Result<Vec<u8>> {
+        let line = request.lines().next().unwrap_or("");
+        let mut parts = line.split_whitespace();
+        let (method, target) = (parts.next().unwrap_or(""), parts.next().unwrap_or(""));
+        let (path, query) = target.split_once('?').unwrap_or((target, ""));
+        let segments: Vec<&str> = path.trim_matches('/').split('/').collect();
+
+        let body = match (method, segments.as_slice()) {
+            ("GET", ["version"]) => self.version(),
+            ("GET", ["containers", "json"]) => self.containers(!query.contains("all=false")),
+            ("GET", ["containers", id, "json"]) => self.inspect(id)?,
+            ("GET", ["containers", id, "stats"]) => self.stats(id)?,
+            ("GET", ["containers", id, "logs"]) => return Ok(self.logs(id, query).into_bytes()),
+            ("GET", ["images", "json"]) => self.images(),
+            ("GET", ["system", "df"]) => json!({ "Volumes": [] }),
+            _ => return Err(anyhow::anyhow!("{} {} is not available in mock mode", method, path)),
+        };
+        Ok(body.to_string().into_bytes())
+    }
+
+    fn container(&self, id: &str) -> Result<&Value> {
+        self.data["containers"]
+            .as_array()
+            .and_then(|list| list.iter().find(|c| c["Id"].as_str().map(|cid| cid.starts_with(id)).unwrap_or(false)))
+            .ok_or_else(|| anyhow::anyhow!("No such container: {}", id))
+    }
+
+    fn version(&self) -> Value {
+        match &self.data["version"] {
+            Value::Null => json!({ "Version": "mock", "ApiVersion": "1.43", "Platform": { "Name": "Docker Engine (mock)" } }),
+            v => v.clone(),
+        }
+    }
+
+    fn containers(&self, all: bool) -> Value {
+        let list = self.data["containers"].as_array().cloned().unwrap_or_default();
+        Value::Array(list.into_iter().filter(|c| all || c["State"] == "running").collect())
+    }
+
+    // One image per distinct container image, created a day before its oldest container
+    fn images(&self) -> Value {
+        if let Some(images) = self.data.get("images") {
+            return images.clone();
+        }
+        let mut images: Vec<Value> = Vec::new();
+        for c in self.data["containers"].as_array().cloned().unwrap_or_default() {
+            let name = c["Image"].as_str().unwrap_or("").to_string();
+            let tag = if name.contains(':') { name.clone() } else { format!("{}:latest", name) };
+            let created = c["Created"].as_i64().unwrap_or(0) - 86400;
+            match images.iter_mut().find(|i| i["RepoTags"][0] == tag.as_str()) {
+                Some(image) => {
+                    if created < image["Created"].as_i64().unwrap_or(0) {
+                        image["Created"] = json!(created);
+                    }
+                }
+                None => {
+                    // Stable per name, so the sizes don't shuffle between fetches
+                    let seed = name.bytes().fold(0u64, |acc, b| acc.wrapping_mul(31).wrapping_add(b as u64));
+                    images.push(json!({
+                        "Id": format!("sha256:{:064x}", seed),
+                        "RepoTags": [tag],
+                        "RepoDigests": [],
+                        "Size": 20_000_000 + seed % 400_000_000,
+                        "Created": created,
+                    }));
+                }
+            }
+        }
+        Value::Array(images)
+    }
+
+    fn inspect(&self, id: &str) -> Result<Value> {
+        let c = self.container(id)?;
+        let full_id = c["Id"].as_str().unwrap_or(id);
+        if let Some(inspect) = self.data["inspect"].get(full_id) {
+            return Ok(inspect.clone());
+        }
+        // Enough of an inspect payload for the details panel; the exit code comes from "Exited (1) ..."
+        let exit_code = c["Status"].as_str()
+            .and_then(|s| s.strip_prefix("Exited ("))
+            .and_then(|s| s.split(')').next())
+            .and_then(|s| s.parse::<i64>().ok())
+            .unwrap_or(0);
+        // Ports in inspect's shape: {"80/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8080"}]}
+        let mut ports = serde_json::Map::new();
+        for p in c["Ports"].as_array().cloned().unwrap_or_default() {
+            let key = format!("{}/{}", p["PrivatePort"], p["Type"].as_str().unwrap_or("tcp"));
+            let binding = p["PublicPort"].as_u64().map(|public| json!([{ "HostIp": p["IP"].as_str().unwrap_or(""), "HostPort": public.to_string() }]));
+            ports.insert(key, binding.unwrap_or(Value::Null));
+        }
+        // Only when the list status says there is a healthcheck, like the daemon
+        let health = ["healthy", "unhealthy", "starting"]
+            .into_iter()
+            .find(|h| c["Status"].as_str().map(|s| s.contains(&format!("({})", h)) || s.contains(&format!("(health: {})", h))).unwrap_or(false))
+            .map(|h| json!({ "Status": h, "FailingStreak": if h == "unhealthy" { 3 } else { 0 } }));
+        // Started right after creation; stopped ones finished an hour later
+        let created = c["Created"].as_i64().and_then(|t| chrono::DateTime::from_timestamp(t, 0)).map(|t| t.to_rfc3339());
+        let finished = c["Created"].as_i64().and_then(|t| chrono::DateTime::from_timestamp(t + 3600, 0)).map(|t| t.to_rfc3339());
+        Ok(json!({
+            "Id": full_id,
+            "Name": c["Names"][0],
+            "Created": created,
+            "Config": { "Image": c["Image"], "Env": [] },
+            "HostConfig": { "RestartPolicy": { "Name": "no" } },
+            "Mounts": c["Mounts"],
+            "NetworkSettings": { "Ports": ports },
+            "RestartCount": 0,
+            "State": { "Status": c["State"], "ExitCode": exit_code, "Error": "", "OOMKilled": false, "StartedAt": created, "FinishedAt": finished, "Health": health },
+        }))
+    }
+
+    // Counters grow with wall-clock time at the configured rates (CPU wobbling a little around
+    // its target), so consecutive samples produce moving graphs
+    fn stats(&self, id: &str) -> Result<Value> {
+        let c = self.container(id)?;
+        let full_id = c["Id"].as_str().unwrap_or(id);
+        let spec = &self.data["stats"][full_id];
+        let running = c["State"] == "running";
+        let cpu = if running { spec["cpu_percent"].as_f64().unwrap_or(1.0) / 100.0 } else { 0.0 };
+        let rate = |key: &str| if running { spec[key].as_f64().unwrap_or(0.0) } else { 0.0 };
+
+        let now = self.started.elapsed().as_secs_f64() + 1.0;
+        let sample = |t: f64| {
+            let system = (t * 1e9) as u64;
+            let wobble = if cpu > 0.0 { cpu * 0.2 * (t * 0.7).sin() } else { 0.0 };
+            let total = (((cpu * t) + wobble).max(0.0) * 1e9) as u64;
+            json!({ "cpu_usage": { "total_usage": total }, "system_cpu_usage": system })
+        };
+        Ok(json!({
+            "read": chrono::Utc::now().to_rfc3339(),
+            "cpu_stats": sample(now),
+            "precpu_stats": sample(now - 1.0),
+            "memory_stats": {
+                "usage": spec["memory_usage"].as_u64().filter(|_| running).unwrap_or(0),
+                "limit": spec["memory_limit"].as_u64().unwrap_or(0),
+            },
+            "networks": {
+                "eth0": { "rx_bytes": (rate("rx_rate") * now) as u64, "tx_bytes": (rate("tx_rate") * now) as u64 },
+            },
+        }))
+    }
+
+    // Plain text, which demux_output accepts as non-multiplexed output
+    fn logs(&self, id: &str, query: &str) -> String {
+        let full_id = self.container(id).ok().and_then(|c| c["Id"].as_str()).unwrap_or(id);
+        let lines: Vec<&str> = self.data["logs"][full_id]
+            .as_array()
+            .map(|l| l.iter().filter_map(|v| v.as_str()).collect())
+            .unwrap_or_default();
+        let tail = query
+            .split('&')
+            .find_map(|kv| kv.strip_prefix("tail="))
+            .and_then(|t| t.parse::<usize>().ok())
+            .unwrap_or(lines.len());
+        let lines = &lines[lines.len().saturating_sub(tail)..];
+        // All stamped with one fixed moment, which any `since` a poll sends back includes
+        let mut out = if query.contains("timestamps=true") {
+            lines.iter().map(|l| format!("{} {}", FIXTURE_LOG_TIME, l)).collect::<Vec<_>>().join("\n")
+        } else {
+            lines.join("\n")
+        };
+        out.push('\n');
+        out
+    }
+}
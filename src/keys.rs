@@ -24,6 +24,11 @@ pub fn key_matches(event: KeyEvent, binding: &str) -> bool {
 }
 
 pub fn parse_key(binding: &str) -> Option<(KeyCode, KeyModifiers)> {
+    // Single characters keep their case so "E" and "e" can be bound to different actions
+    if binding.chars().count() == 1 {
+        return binding.chars().next().map(|c| (KeyCode::Char(c), KeyModifiers::empty()));
+    }
+
     let binding = binding.to_lowercase();
     let parts: Vec<&str> = binding.split('+').collect();
     
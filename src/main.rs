@@ -5,7 +5,7 @@ use crossterm::{
     execute,
     terminal::{disable_raw_mode, enable_raw_mode, EnterAlternateScreen, LeaveAlternateScreen},
 };
-use ratatui::{backend::CrosstermBackend, Terminal};
+use ratatui::{backend::CrosstermBackend, layout::Rect, Terminal, TerminalOptions, Viewport};
 use tokio::sync::{mpsc, watch};
 use tokio::io::AsyncReadExt;
 // Removed unused imports
@@ -18,11 +18,14 @@ mod theme;
 mod action;
 pub mod wizard;
 mod keys;
+mod clipboard;
+mod notify;
+mod fixture;
 
 use action::Action;
 
-use app::App;
-use docker::{Container, ContainerStats, ContainerInspection, DockerClient};
+use app::{App, LogMode, LogStatus};
+use docker::{Container, ContainerStats, ContainerInspection, DockerClient, DockerEvent, ExecOutput, ImageSummary, InspectCache, ServerInfo};
 
 fn update_docktop() -> Result<(), Box<dyn std::error::Error>> {
     let status = self_update::backends::github::Update::configure()
@@ -38,35 +41,35 @@ fn update_docktop() -> Result<(), Box<dyn std::error::Error>> {
     Ok(())
 }
 
-fn enter_container_shell(container_id: &str, terminal: &mut Terminal<CrosstermBackend<io::Stdout>>, cli_path: &str) -> io::Result<()> {
+// Returns a status message when no shell could be started
+fn enter_container_shell(container_id: &str, terminal: &mut Terminal<CrosstermBackend<io::Stdout>>, cli_path: &str) -> io::Result<Option<String>> {
     disable_raw_mode()?;
     execute!(io::stdout(), LeaveAlternateScreen, DisableMouseCapture)?;
 
     println!("Entering container shell for {}...", container_id);
-    
-    // Try bash first
-    let status = std::process::Command::new(cli_path)
-        .arg("exec")
-        .arg("-it")
-        .arg(container_id)
-        .arg("/bin/bash")
-        .status();
-
-    // If bash fails, try sh
-    if status.is_err() || !status.unwrap().success() {
-        println!("Bash failed, trying sh...");
-        let _ = std::process::Command::new(cli_path)
+
+    // Try bash first, then sh. `docker exec` exits 126/127 when the command can't be run;
+    // any other exit code is the shell's own, e.g. the last command typed before `exit`.
+    let mut failure = Some(format!("Failed to open shell: neither /bin/bash nor /bin/sh exists in {}", container_id));
+    for shell in ["/bin/bash", "/bin/sh"] {
+        let status = std::process::Command::new(cli_path)
             .arg("exec")
             .arg("-it")
             .arg(container_id)
-            .arg("/bin/sh")
+            .arg(shell)
             .status();
+        match status {
+            Ok(s) if matches!(s.code(), Some(126) | Some(127)) => continue,
+            Ok(_) => failure = None,
+            Err(e) => failure = Some(format!("Failed to open shell: {}: {}", cli_path, e)),
+        }
+        break;
     }
 
     enable_raw_mode()?;
     execute!(io::stdout(), EnterAlternateScreen, EnableMouseCapture)?;
     terminal.clear()?;
-    Ok(())
+    Ok(failure)
 }
 
 fn enter_database_cli(container_id: &str, image: &str, terminal: &mut Terminal<CrosstermBackend<io::Stdout>>, cli_path: &str) -> io::Result<()> {
@@ -112,8 +115,239 @@ fn enter_database_cli(container_id: &str, image: &str, terminal: &mut Terminal<C
     Ok(())
 }
 
-#[tokio::main]
-async fn main() -> Result<()> {
+// Forwards a (possibly multiplexed) log stream line by line until it closes or the receiver goes away
+// Lines go out as (is_stderr, line); multiplexed frames say which stream they came from
+async fn forward_log_stream(mut stream: docker::DaemonStream, tx: mpsc::Sender<(bool, String)>) {
+    let mut header = [0u8; 8];
+    if stream.read_exact(&mut header).await.is_err() { return; }
+    
+    let is_multiplexed = header[0] <= 2 && header[1] == 0 && header[2] == 0 && header[3] == 0;
+    
+    if is_multiplexed {
+        let size = u32::from_be_bytes([header[4], header[5], header[6], header[7]]) as usize;
+        if size < 10_000_000 {
+            let mut payload = vec![0u8; size];
+            if stream.read_exact(&mut payload).await.is_ok() {
+                let stderr = header[0] == 2;
+                let line = String::from_utf8_lossy(&payload).to_string();
+                for l in line.lines() { if tx.send((stderr, l.to_string())).await.is_err() { return; } }
+            }
+        }
+        loop {
+            if stream.read_exact(&mut header).await.is_err() { break; }
+            let size = u32::from_be_bytes([header[4], header[5], header[6], header[7]]) as usize;
+            if size > 10_000_000 { break; }
+            let mut payload = vec![0u8; size];
+            if stream.read_exact(&mut payload).await.is_err() { break; }
+            let stderr = header[0] == 2;
+            let line = String::from_utf8_lossy(&payload).to_string();
+            for l in line.lines() { if tx.send((stderr, l.to_string())).await.is_err() { return; } }
+        }
+    } else {
+        // TTY containers send raw text with stdout and stderr already merged
+        let chunk = String::from_utf8_lossy(&header).to_string();
+        if tx.send((false, chunk)).await.is_err() { return; }
+        let mut buffer = [0u8; 1024];
+        loop {
+            match stream.read(&mut buffer).await {
+                Ok(0) => break,
+                Ok(n) => {
+                    let s = String::from_utf8_lossy(&buffer[..n]).to_string();
+                    for line in s.split_inclusive('\n') {
+                        if tx.send((false, line.to_string())).await.is_err() { return; }
+                    }
+                }
+                Err(_) => break,
+            }
+        }
+    }
+}
+
+// Feeds one container's stats to the strip, at most one sample per `interval` from its stats
+// stream. Where streaming isn't available (mock mode, proxies that cut long responses) it falls
+// back to a one-shot request per interval. Failures are reported too, so a running container
+// without stats isn't mistaken for an idle one.
+async fn stream_stats(client: std::sync::Arc<DockerClient>, id: String, interval: Duration, tx: mpsc::Sender<(String, Option<ContainerStats>)>) {
+    let mut stream = match client.get_stats_stream(&id).await {
+        Ok(stream) => stream,
+        Err(_) => loop {
+            if tx.send((id.clone(), client.get_stats(&id).await.ok())).await.is_err() {
+                return;
+            }
+            tokio::time::sleep(interval).await;
+        },
+    };
+    let mut buffer = [0u8; 8192];
+    let mut pending = Vec::new();
+    let mut last_sent: Option<std::time::Instant> = None;
+    // The stream's first sample has empty precpu_stats, so its CPU figure would be bogus
+    let mut first = true;
+    loop {
+        match stream.read(&mut buffer).await {
+            Ok(0) | Err(_) => break, // Stopped, or the stream broke off
+            Ok(n) => {
+                pending.extend_from_slice(&buffer[..n]);
+                while let Some(pos) = pending.iter().position(|&b| b == b'\n') {
+                    let line: Vec<u8> = pending.drain(..=pos).collect();
+                    if std::mem::take(&mut first) || last_sent.map(|t| t.elapsed() < interval).unwrap_or(false) {
+                        continue;
+                    }
+                    if let Ok(stats) = serde_json::from_slice::<ContainerStats>(&line) {
+                        if tx.send((id.clone(), Some(stats))).await.is_err() {
+                            return;
+                        }
+                        last_sent = Some(std::time::Instant::now());
+                    }
+                }
+            }
+        }
+    }
+}
+
+// Points the details/logs fetchers at the selected container after the list changed under the
+// cursor, without waking them when the selection is unchanged
+fn retarget(app: &App, tx_target: &watch::Sender<Option<String>>) {
+    let selected = app.get_selected_container().map(|c| c.id.clone());
+    if *tx_target.borrow() != selected {
+        let _ = tx_target.send(selected);
+    }
+}
+
+// How often to try the daemon again while it can't be reached
+const DAEMON_RETRY: Duration = Duration::from_secs(3);
+
+// How long the cursor has to rest on a container before its stats and inspect are fetched
+const DETAILS_DEBOUNCE: Duration = Duration::from_millis(150);
+
+// One-shot image list for the images view; the result is picked up in the tick
+fn fetch_images(client: &std::sync::Arc<DockerClient>, tx: &mpsc::Sender<Result<Vec<ImageSummary>, String>>) {
+    let client = client.clone();
+    let tx = tx.clone();
+    tokio::spawn(async move {
+        let _ = tx.send(client.list_images().await.map_err(|e| e.to_string())).await;
+    });
+}
+
+// Inspects through the shared cache; the lock is never held across the daemon round-trip
+async fn inspect_cached(client: &DockerClient, cache: &std::sync::Mutex<InspectCache>, id: &str, state: &str) -> Option<ContainerInspection> {
+    if let Some(cached) = cache.lock().unwrap().get(id, state) {
+        return Some(cached);
+    }
+    let fresh = client.inspect_container(id).await.ok()?;
+    cache.lock().unwrap().insert(id, state, fresh.clone());
+    Some(fresh)
+}
+
+// Re-fetches the log tail on the refresh interval and forwards only lines not seen in the previous
+// fetch. With the interval off it fetches once and waits until the interval is changed.
+async fn poll_logs(client: &DockerClient, container_id: &str, tail: usize, mode: LogMode, mut refresh: watch::Receiver<u64>, tx: mpsc::Sender<(bool, String)>, tx_status: mpsc::Sender<LogStatus>) {
+    // Where the last fetch ended: the newest line's timestamp, and how many lines sent so far carry
+    // exactly that timestamp (the next fetch starts at it, so they come back again)
+    let mut last: Option<(String, usize)> = None;
+    let mut ok = None;
+    loop {
+        let result = client.get_logs(container_id, tail, last.as_ref().map(|(ts, _)| ts.as_str())).await;
+        // Only report transitions so the panel isn't flooded with identical updates
+        if ok != Some(result.is_ok()) {
+            ok = Some(result.is_ok());
+            let status = match &result {
+                Ok(_) => LogStatus::Connected(mode),
+                Err(e) if docker::is_gone(e) => LogStatus::Gone,
+                Err(e) => LogStatus::Failed(e.to_string()),
+            };
+            let _ = tx_status.send(status).await;
+        }
+        if let Ok(lines) = result {
+            let mut repeated = last.as_ref().map(|(_, n)| *n).unwrap_or(0);
+            for (is_stderr, line) in lines {
+                let (ts, text) = line.split_once(' ').unwrap_or((line.as_str(), ""));
+                match &mut last {
+                    Some((last_ts, _)) if last_ts == ts && repeated > 0 => {
+                        repeated -= 1;
+                        continue;
+                    }
+                    Some((last_ts, n)) if last_ts == ts => *n += 1,
+                    _ => last = Some((ts.to_string(), 1)),
+                }
+                if tx.send((is_stderr, text.to_string())).await.is_err() { return; }
+            }
+        }
+        let secs = *refresh.borrow_and_update();
+        if secs == 0 {
+            if refresh.changed().await.is_err() { return; }
+        } else {
+            tokio::select! {
+                _ = tokio::time::sleep(Duration::from_secs(secs)) => {}
+                res = refresh.changed() => { if res.is_err() { return; } }
+            }
+        }
+    }
+}
+
+// --host, then DOCKER_HOST, then the config's socket_path. TLS follows the docker CLI: --tlsverify,
+// any of the certificate flags or DOCKER_TLS_VERIFY turn it on for tcp:// hosts (default port
+// 2376), and each certificate not given defaults to ca.pem, cert.pem or key.pem in DOCKER_CERT_PATH
+// (or ~/.docker).
+fn daemon_host(overrides: &config::CliOverrides) -> Result<(docker::DaemonHost, Option<docker::TlsFiles>)> {
+    let tls_env = std::env::var("DOCKER_TLS_VERIFY").map(|v| !v.is_empty() && v != "0").unwrap_or(false);
+    let tls_flags = overrides.tls_verify || overrides.tls_cacert.is_some() || overrides.tls_cert.is_some() || overrides.tls_key.is_some();
+    if overrides.tls && !tls_flags && !tls_env {
+        return Err(anyhow::anyhow!("--tls connects without checking the daemon's certificate, which docktop doesn't do; use --tlsverify with --tlscacert instead"));
+    }
+    let tls = tls_flags || tls_env;
+    let host = overrides.host.clone()
+        .or_else(|| std::env::var("DOCKER_HOST").ok().filter(|h| !h.is_empty()))
+        .unwrap_or_else(|| config::Config::load().docker.socket_path);
+    let host = docker::DaemonHost::parse_with_port(&host, if tls { 2376 } else { 2375 })?;
+    if !tls {
+        return Ok((host, None));
+    }
+    if !matches!(host, docker::DaemonHost::Tcp(_)) {
+        if tls_flags {
+            return Err(anyhow::anyhow!("TLS flags only apply to tcp:// hosts, not {}", host.label()));
+        }
+        // DOCKER_TLS_VERIFY is meant for the remote hosts it's exported for, not the local socket
+        return Ok((host, None));
+    }
+    let dir = std::env::var("DOCKER_CERT_PATH").ok().filter(|d| !d.is_empty()).map(std::path::PathBuf::from)
+        .or_else(|| std::env::var("HOME").ok().map(|home| std::path::Path::new(&home).join(".docker")))
+        .unwrap_or_default();
+    let file = |flag: &Option<String>, name: &str| flag.as_ref().map(std::path::PathBuf::from).unwrap_or_else(|| dir.join(name));
+    let files = docker::TlsFiles {
+        ca: file(&overrides.tls_cacert, "ca.pem"),
+        cert: file(&overrides.tls_cert, "cert.pem"),
+        key: file(&overrides.tls_key, "key.pem"),
+    };
+    Ok((host, Some(files)))
+}
+
+// Some SSH/CI terminals report 0x0 (or fail the size query) until they are resized, which would
+// leave nothing to draw on. Returns a stand-in size from $COLUMNS/$LINES, or 80x24, in that case.
+fn fallback_terminal_size() -> Option<Rect> {
+    if crossterm::terminal::size().map(|(w, h)| w > 0 && h > 0).unwrap_or(false) {
+        return None;
+    }
+    let env_size = |var: &str, default: u16| {
+        std::env::var(var).ok().and_then(|v| v.parse::<u16>().ok()).filter(|v| *v > 0).unwrap_or(default)
+    };
+    Some(Rect::new(0, 0, env_size("COLUMNS", 80), env_size("LINES", 24)))
+}
+
+// How long quitting waits for requests and commands still in flight before leaving them behind
+const SHUTDOWN_GRACE: Duration = Duration::from_millis(500);
+
+fn main() -> Result<()> {
+    let runtime = tokio::runtime::Runtime::new()?;
+    let result = runtime.block_on(run());
+    // Dropping the runtime would wait for every task to let go of its thread, including a
+    // `docker build` or compose run blocking the action loop, or a request to a daemon that
+    // stopped answering. Give them a moment, then exit without them.
+    runtime.shutdown_timeout(SHUTDOWN_GRACE);
+    docker::stop_tls_relay();
+    result
+}
+
+async fn run() -> Result<()> {
     // Check for update arg
     let args: Vec<String> = std::env::args().collect();
     if args.len() > 1 && args[1] == "update" {
@@ -125,68 +359,176 @@ async fn main() -> Result<()> {
         std::process::exit(0);
     }
 
+    // Resolve the data source before taking over the terminal, so a bad fixture is reported plainly
+    let overrides = config::CliOverrides::parse(&args);
+    if overrides.mock.is_none() {
+        match daemon_host(&overrides) {
+            Ok((host, tls)) => {
+                // Shells and the docker CLI started from here should reach the same daemon
+                if overrides.host.is_some() {
+                    std::env::set_var("DOCKER_HOST", host.label());
+                }
+                docker::set_daemon_host(host.clone());
+                if let (docker::DaemonHost::Tcp(addr), Some(files)) = (&host, &tls) {
+                    match docker::set_daemon_tls(files, addr).and_then(|_| docker::start_tls_relay()) {
+                        // The relay does the TLS for the CLI children, which reach it as a plain socket
+                        Ok(relay) => {
+                            std::env::set_var("DOCKER_HOST", format!("unix://{}", relay));
+                            std::env::remove_var("DOCKER_TLS_VERIFY");
+                        }
+                        Err(e) => {
+                            eprintln!("{}", e);
+                            std::process::exit(1);
+                        }
+                    }
+                }
+            }
+            Err(e) => {
+                eprintln!("{}", e);
+                std::process::exit(1);
+            }
+        }
+    }
+    let docker_client = match &overrides.mock {
+        Some(path) => match DockerClient::from_fixture(path) {
+            Ok(client) => client,
+            Err(e) => {
+                eprintln!("{}", e);
+                std::process::exit(1);
+            }
+        },
+        None => DockerClient::new(),
+    };
+
     // Setup Terminal
     enable_raw_mode()?;
     let mut stdout = io::stdout();
     execute!(stdout, EnterAlternateScreen, EnableMouseCapture)?;
     let backend = CrosstermBackend::new(stdout);
-    let mut terminal = Terminal::new(backend)?;
+    let mut fixed_size = fallback_terminal_size();
+    let mut terminal = match fixed_size {
+        Some(area) => Terminal::with_options(backend, TerminalOptions { viewport: Viewport::Fixed(area) })?,
+        None => Terminal::new(backend)?,
+    };
+
+    // App State (created early so background tasks can read config)
+    let mut app = App::new();
+    app.apply_startup(&overrides);
 
     // Channels
     let (tx_containers, mut rx_containers) = mpsc::channel::<Vec<Container>>(10);
-    let (tx_details, mut rx_details) = mpsc::channel::<(Option<ContainerStats>, Option<ContainerInspection>)>(10);
-    let (tx_logs, mut rx_logs) = mpsc::channel::<String>(100);
+    let (tx_details, mut rx_details) = mpsc::channel::<(String, Option<ContainerStats>, Option<ContainerInspection>)>(10);
+    let (tx_logs, mut rx_logs) = mpsc::channel::<(bool, String)>(100);
     let (tx_target, rx_target) = watch::channel::<Option<String>>(None);
+    let (tx_log_mode, mut rx_log_mode) = watch::channel::<LogMode>(app.log_mode);
+    let (tx_log_refresh, rx_log_refresh) = watch::channel::<u64>(app.log_refresh_secs);
+    let (tx_log_status, mut rx_log_status) = mpsc::channel::<LogStatus>(10);
+    let (tx_log_reconnect, mut rx_log_reconnect) = watch::channel::<u64>(0);
+    let mut log_generation: u64 = 0;
+    let (tx_watch_file, mut rx_watch_file) = watch::channel::<Option<(String, String)>>(None);
+    let (tx_states, rx_states) = watch::channel::<std::collections::HashMap<String, String>>(std::collections::HashMap::new());
     let (tx_action, rx_action) = mpsc::channel::<Action>(10);
     let (tx_action_result, mut rx_action_result) = mpsc::channel::<String>(10);
+    let (tx_action_done, mut rx_action_done) = mpsc::channel::<action::ActionDone>(10);
     let (tx_janitor_items, mut rx_janitor_items) = mpsc::channel::<Vec<crate::wizard::models::JanitorItem>>(10);
     let (tx_refresh, mut rx_refresh) = mpsc::channel::<()>(1);
+    let (tx_server_info, mut rx_server_info) = mpsc::channel::<ServerInfo>(1);
+    let (tx_exec_output, mut rx_exec_output) = mpsc::channel::<ExecOutput>(1);
+    let (tx_privileged, mut rx_privileged) = mpsc::channel::<std::collections::HashMap<String, Vec<String>>>(1);
+    let (tx_restart_counts, mut rx_restart_counts) = mpsc::channel::<std::collections::HashMap<String, u64>>(1);
+    let (tx_volume_sizes, mut rx_volume_sizes) = mpsc::channel::<std::collections::HashMap<String, u64>>(1);
+    let (tx_compare, rx_compare) = watch::channel::<Vec<String>>(Vec::new());
+    let (tx_compare_stats, mut rx_compare_stats) = mpsc::channel::<(String, ContainerStats)>(10);
+    let (tx_strip, rx_strip) = watch::channel::<bool>(app.wants_usage_stats());
+    let (tx_strip_stats, mut rx_strip_stats) = mpsc::channel::<(String, Option<ContainerStats>)>(100);
+    let (tx_events, mut rx_events) = mpsc::channel::<DockerEvent>(100);
+    let (tx_inspect_json, mut rx_inspect_json) = mpsc::channel::<(String, Result<String>)>(1);
+    let (tx_inspect_view, mut rx_inspect_view) = mpsc::channel::<(String, Result<String>)>(1);
+    let (tx_daemon_down, mut rx_daemon_down) = watch::channel::<Option<String>>(None);
+    let (tx_images, mut rx_images) = mpsc::channel::<Result<Vec<ImageSummary>, String>>(1);
+    let (tx_prune_estimate, mut rx_prune_estimate) = mpsc::channel::<Result<crate::docker::PruneEstimate, String>>(1);
 
     // Docker Client (Shared)
-    let docker_client = std::sync::Arc::new(DockerClient::new());
+    let docker_client = std::sync::Arc::new(docker_client);
     
-    // Task 1: Container Lister (Event Driven + Slow Poll)
-    let client_clone1 = docker_client.clone();
+    // Task 0: Runtime Detection (once, as soon as the daemon answers)
+    let client_clone0 = docker_client.clone();
     tokio::spawn(async move {
-        // Initial fetch
-        if let Ok(containers) = client_clone1.list_containers().await {
-             let _ = tx_containers.send(containers).await;
+        loop {
+            if let Ok(info) = client_clone0.server_info().await {
+                let _ = tx_server_info.send(info).await;
+                break;
+            }
+            tokio::time::sleep(DAEMON_RETRY).await;
         }
+    });
 
+    // Task 1: Container Lister (Event Driven + Slow Poll)
+    let client_clone1 = docker_client.clone();
+    let list_all = app.list_all;
+    let list_sizes = app.list_sizes;
+    tokio::spawn(async move {
+        let mut down = false;
         loop {
+            match client_clone1.list_containers(list_all, list_sizes).await {
+                Ok(containers) => {
+                    if down {
+                        down = false;
+                        let _ = tx_daemon_down.send(None);
+                    }
+                    let _ = tx_states.send(containers.iter().map(|c| (c.id.clone(), c.state.clone())).collect());
+                    if tx_containers.send(containers).await.is_err() {
+                        break;
+                    }
+                }
+                // Keep retrying; the UI recovers by itself once the daemon answers again
+                Err(e) => {
+                    down = true;
+                    let _ = tx_daemon_down.send(Some(docker::describe_unreachable(&e)));
+                }
+            }
+
             tokio::select! {
-                _ = tokio::time::sleep(Duration::from_secs(10)) => {}, // Slow poll
+                _ = tokio::time::sleep(if down { DAEMON_RETRY } else { Duration::from_secs(10) }) => {}, // Slow poll
                 _ = rx_refresh.recv() => {}, // Event triggered
             }
-            
-            if let Ok(containers) = client_clone1.list_containers().await {
-                if tx_containers.send(containers).await.is_err() {
-                    break;
-                }
-            }
         }
     });
 
+    // Browsing between containers reuses recent inspects instead of hitting the daemon each time.
+    // Shared by the details fetcher and the security audit.
+    let inspect_cache = std::sync::Arc::new(std::sync::Mutex::new(InspectCache::new(Duration::from_secs(10))));
+
     // Task 2: Details Fetcher (On Demand + Slow Loop)
+    let stats_interval = app.stats_interval;
     let client_clone2 = docker_client.clone();
     let mut rx_target_details = rx_target.clone();
+    let rx_states_details = rx_states.clone();
+    let inspect_cache_details = inspect_cache.clone();
     tokio::spawn(async move {
         let mut last_fetch = std::time::Instant::now();
         loop {
             let target_changed = rx_target_details.has_changed().unwrap_or(false);
-            let time_to_update = last_fetch.elapsed() >= Duration::from_secs(2);
+            let time_to_update = last_fetch.elapsed() >= stats_interval;
             
             if target_changed || time_to_update {
                 if target_changed {
                     let _ = rx_target_details.borrow_and_update();
+                    // Holding j/k passes over many containers; only fetch the one the cursor stops on
+                    tokio::time::sleep(DETAILS_DEBOUNCE).await;
+                    if rx_target_details.has_changed().unwrap_or(false) {
+                        continue;
+                    }
                 }
                 
                 let target_id = rx_target_details.borrow().clone();
                 if let Some(id) = target_id {
                     let stats = client_clone2.get_stats(&id).await.ok();
-                    let inspect = client_clone2.inspect_container(&id).await.ok();
+
+                    let state = rx_states_details.borrow().get(&id).cloned().unwrap_or_default();
+                    let inspect = inspect_cached(&client_clone2, &inspect_cache_details, &id, &state).await;
                     
-                    if tx_details.send((stats, inspect)).await.is_err() {
+                    if tx_details.send((id, stats, inspect)).await.is_err() {
                         break;
                     }
                     last_fetch = std::time::Instant::now();
@@ -197,74 +539,207 @@ async fn main() -> Result<()> {
         }
     });
 
-    // Task 3: Log Streamer
+    // Task 2b: Security Audit (privileged mode / dangerous capabilities for every container)
+    let client_clone2b = docker_client.clone();
+    let mut rx_states_audit = rx_states.clone();
+    tokio::spawn(async move {
+        // Only containers that are new or changed state since the last pass get re-audited
+        let mut audited: std::collections::HashMap<String, (String, Vec<String>)> = std::collections::HashMap::new();
+        let mut restart_counts: std::collections::HashMap<String, u64> = std::collections::HashMap::new();
+        loop {
+            if rx_states_audit.changed().await.is_err() { break; }
+            let states = rx_states_audit.borrow_and_update().clone();
+            inspect_cache.lock().unwrap().retain_ids(&states);
+            audited.retain(|id, _| states.contains_key(id));
+            restart_counts.retain(|id, _| states.contains_key(id));
+
+            for (id, state) in &states {
+                if audited.get(id).map(|(s, _)| s == state).unwrap_or(false) {
+                    continue;
+                }
+                if let Some(inspect) = inspect_cached(&client_clone2b, &inspect_cache, id, state).await {
+                    audited.insert(id.clone(), (state.clone(), inspect.privilege_warnings()));
+                    // A crash-looping container flaps through "restarting", so each restart is seen here
+                    restart_counts.insert(id.clone(), inspect.restart_count.unwrap_or(0));
+                }
+            }
+
+            let flags = audited
+                .iter()
+                .filter(|(_, (_, w))| !w.is_empty())
+                .map(|(id, (_, w))| (id.clone(), w.clone()))
+                .collect();
+            if tx_privileged.send(flags).await.is_err() { break; }
+            if tx_restart_counts.send(restart_counts.clone()).await.is_err() { break; }
+        }
+    });
+
+    // Task 2c: Volume Usage (system df is expensive, so at most every 30s and only when the target changes)
+    let client_clone2c = docker_client.clone();
+    let mut rx_target_volumes = rx_target.clone();
+    tokio::spawn(async move {
+        let mut last_fetch: Option<std::time::Instant> = None;
+        loop {
+            if rx_target_volumes.changed().await.is_err() { break; }
+            if last_fetch.map(|t| t.elapsed() < Duration::from_secs(30)).unwrap_or(false) {
+                continue;
+            }
+            last_fetch = Some(std::time::Instant::now());
+            if let Ok(sizes) = client_clone2c.volume_sizes().await {
+                if tx_volume_sizes.send(sizes).await.is_err() { break; }
+            }
+        }
+    });
+
+    // Task 2d: Comparison Stats (only while the compare view is open)
+    let client_clone2d = docker_client.clone();
+    let mut rx_compare_ids = rx_compare.clone();
+    tokio::spawn(async move {
+        loop {
+            let ids = rx_compare_ids.borrow_and_update().clone();
+            if ids.is_empty() {
+                if rx_compare_ids.changed().await.is_err() { break; }
+                continue;
+            }
+            for id in &ids {
+                if let Ok(stats) = client_clone2d.get_stats(id).await {
+                    if tx_compare_stats.send((id.clone(), stats)).await.is_err() { return; }
+                }
+            }
+            tokio::select! {
+                _ = tokio::time::sleep(stats_interval) => {},
+                res = rx_compare_ids.changed() => { if res.is_err() { break; } }
+            }
+        }
+    });
+
+    // Task 2e: Stats Strip (every running container, only while the strip is shown or the list sorts by usage).
+    // One long-lived stats stream per running container, opened when it shows up running and
+    // dropped when it stops or goes away, rather than a fresh request for each of them every interval.
+    let client_clone2e = docker_client.clone();
+    let mut rx_strip_enabled = rx_strip.clone();
+    let mut rx_states_strip = rx_states.clone();
+    tokio::spawn(async move {
+        let mut streams: std::collections::HashMap<String, tokio::task::JoinHandle<()>> = std::collections::HashMap::new();
+        loop {
+            let running: std::collections::HashSet<String> = if *rx_strip_enabled.borrow_and_update() {
+                rx_states_strip.borrow_and_update().iter()
+                    .filter(|(_, state)| state.as_str() == "running")
+                    .map(|(id, _)| id.clone())
+                    .collect()
+            } else {
+                std::collections::HashSet::new()
+            };
+            // A stream that broke off is opened again with the next list
+            streams.retain(|id, task| {
+                let keep = running.contains(id) && !task.is_finished();
+                if !keep {
+                    task.abort();
+                }
+                keep
+            });
+            for id in running {
+                if !streams.contains_key(&id) {
+                    let task = tokio::spawn(stream_stats(client_clone2e.clone(), id.clone(), stats_interval, tx_strip_stats.clone()));
+                    streams.insert(id, task);
+                }
+            }
+            tokio::select! {
+                res = rx_strip_enabled.changed() => { if res.is_err() { break; } }
+                res = rx_states_strip.changed() => { if res.is_err() { break; } }
+            }
+        }
+        for task in streams.values() {
+            task.abort();
+        }
+    });
+
+    // Task 3: Log Streamer (container logs, or a file followed via exec while watching)
     let client_clone3 = docker_client.clone();
     let mut rx_target_logger = rx_target.clone();
+    let rx_states_logger = rx_states.clone();
+    let rx_refresh_logger = rx_log_refresh.clone();
+    let log_tail_lines = app.config.general.log_tail_lines;
 
     let tx_logs_streamer = tx_logs.clone();
     tokio::spawn(async move {
         let mut current_log_task: Option<tokio::task::JoinHandle<()>> = None;
-        let mut last_id: Option<String> = None;
+        let mut last_source: (Option<String>, Option<String>, LogMode, u64) = (None, None, LogMode::Stream, 0);
+        let mut watch_path: Option<String> = None;
 
         loop {
-            if rx_target_logger.changed().await.is_err() { break; }
-            let new_id = rx_target_logger.borrow().clone();
+            tokio::select! {
+                res = rx_target_logger.changed() => {
+                    if res.is_err() { break; }
+                    // Navigating away stops any file watch, including one requested for the old target
+                    let _ = rx_watch_file.borrow_and_update();
+                    watch_path = None;
+                }
+                res = rx_watch_file.changed() => {
+                    if res.is_err() { break; }
+                    let target = rx_target_logger.borrow().clone();
+                    watch_path = rx_watch_file.borrow_and_update().clone()
+                        .filter(|(id, _)| Some(id) == target.as_ref())
+                        .map(|(_, path)| path);
+                }
+                res = rx_log_mode.changed() => {
+                    if res.is_err() { break; }
+                }
+                res = rx_log_reconnect.changed() => {
+                    if res.is_err() { break; }
+                }
+            }
+            let mode = *rx_log_mode.borrow_and_update();
+            let generation = *rx_log_reconnect.borrow_and_update();
+            let new_source = (rx_target_logger.borrow().clone(), watch_path.clone(), mode, generation);
 
-            if new_id != last_id {
+            if new_source != last_source {
                 if let Some(task) = current_log_task.take() {
                     task.abort();
                 }
                 
-                if let Some(id) = new_id.clone() {
+                if let Some(id) = new_source.0.clone() {
                     let client = client_clone3.clone();
                     let tx = tx_logs_streamer.clone();
+                    let tx_status = tx_log_status.clone();
+                    let path = new_source.1.clone();
+                    let stopped = rx_states_logger.borrow().get(&id).map(|s| App::is_stopped(s)).unwrap_or(false);
+                    let refresh = rx_refresh_logger.clone();
                     
                     current_log_task = Some(tokio::spawn(async move {
-                        if let Ok(mut stream) = client.get_logs_stream(&id).await {
-                             let mut header = [0u8; 8];
-                             if stream.read_exact(&mut header).await.is_err() { return; }
-                             
-                             let is_multiplexed = header[0] <= 2 && header[1] == 0 && header[2] == 0 && header[3] == 0;
-                             
-                             if is_multiplexed {
-                                 let size = u32::from_be_bytes([header[4], header[5], header[6], header[7]]) as usize;
-                                 if size < 10_000_000 {
-                                     let mut payload = vec![0u8; size];
-                                     if stream.read_exact(&mut payload).await.is_ok() {
-                                         let line = String::from_utf8_lossy(&payload).to_string();
-                                         for l in line.lines() { if tx.send(l.to_string()).await.is_err() { return; } }
-                                     }
-                                 }
-                                 loop {
-                                     if stream.read_exact(&mut header).await.is_err() { break; }
-                                     let size = u32::from_be_bytes([header[4], header[5], header[6], header[7]]) as usize;
-                                     if size > 10_000_000 { break; }
-                                     let mut payload = vec![0u8; size];
-                                     if stream.read_exact(&mut payload).await.is_err() { break; }
-                                     let line = String::from_utf8_lossy(&payload).to_string();
-                                     for l in line.lines() { if tx.send(l.to_string()).await.is_err() { return; } }
-                                 }
-                             } else {
-                                 let chunk = String::from_utf8_lossy(&header).to_string();
-                                 if tx.send(chunk).await.is_err() { return; }
-                                 let mut buffer = [0u8; 1024];
-                                 loop {
-                                     match stream.read(&mut buffer).await {
-                                         Ok(0) => break,
-                                         Ok(n) => {
-                                             let s = String::from_utf8_lossy(&buffer[..n]).to_string();
-                                             for line in s.split_inclusive('\n') {
-                                                 if tx.send(line.to_string()).await.is_err() { return; }
-                                             }
-                                         }
-                                         Err(_) => break,
-                                     }
-                                 }
-                             }
+                        if let Some(path) = path {
+                            match client.exec_stream(&id, &["tail", "-n", "100", "-F", &path]).await {
+                                Ok(stream) => {
+                                    let _ = tx_status.send(LogStatus::Connected(LogMode::Stream)).await;
+                                    forward_log_stream(stream, tx).await;
+                                }
+                                Err(e) => { let _ = tx_status.send(LogStatus::Failed(e.to_string())).await; }
+                            }
+                            return;
+                        }
+
+                        // Following a stopped container never yields anything new, so re-fetch a bounded
+                        // tail on the refresh interval instead
+                        if stopped {
+                            poll_logs(&client, &id, log_tail_lines, LogMode::Tail, refresh, tx, tx_status).await;
+                            return;
+                        }
+
+                        if mode == LogMode::Stream {
+                            match client.get_logs_stream(&id, log_tail_lines).await {
+                                Ok(stream) => {
+                                    let _ = tx_status.send(LogStatus::Connected(LogMode::Stream)).await;
+                                    forward_log_stream(stream, tx).await;
+                                    return;
+                                }
+                                // Streaming blocked (e.g. by a proxy): fall back to polling
+                                Err(_) => {}
+                            }
                         }
+                        poll_logs(&client, &id, log_tail_lines, LogMode::Poll, refresh, tx, tx_status).await;
                     }));
                 }
-                last_id = new_id;
+                last_source = new_source;
             }
         }
     });
@@ -276,12 +751,22 @@ async fn main() -> Result<()> {
         loop {
             if let Ok(mut stream) = client_clone4.get_events_stream().await {
                 let mut buffer = [0u8; 1024];
+                let mut pending = Vec::new();
                 loop {
                     match stream.read(&mut buffer).await {
                         Ok(0) => break, // Connection closed
-                        Ok(_) => {
-                            // Any data means an event occurred
-                            let _ = tx_refresh_clone.send(()).await;
+                        Ok(n) => {
+                            // Events arrive as newline-delimited JSON
+                            pending.extend_from_slice(&buffer[..n]);
+                            while let Some(pos) = pending.iter().position(|&b| b == b'\n') {
+                                let line: Vec<u8> = pending.drain(..=pos).collect();
+                                if let Ok(event) = serde_json::from_slice::<DockerEvent>(&line) {
+                                    if event.type_ == "container" {
+                                        let _ = tx_refresh_clone.send(()).await;
+                                    }
+                                    let _ = tx_events.send(event).await;
+                                }
+                            }
                         }
                         Err(_) => break,
                     }
@@ -293,10 +778,9 @@ async fn main() -> Result<()> {
     });
 
     // Task 5: Action Executor
-    tokio::spawn(action::run_action_loop(rx_action, tx_action_result, tx_janitor_items, tx_refresh, tx_logs.clone()));
+    tokio::spawn(action::run_action_loop(rx_action, tx_action_result, tx_action_done, tx_janitor_items, tx_refresh, tx_logs.clone(), docker_client.clone()));
 
     // App State
-    let mut app = App::new();
     let mut last_tick = std::time::Instant::now();
     let mut last_user_event = std::time::Instant::now();
     let idle_timeout = Duration::from_secs(5);
@@ -319,7 +803,16 @@ async fn main() -> Result<()> {
 
         if crossterm::event::poll(timeout)? {
             last_user_event = std::time::Instant::now();
-            if let Event::Key(key) = event::read()? {
+            let event = event::read()?;
+            // The first real size report replaces the stand-in size with a normal full-screen terminal
+            if let Event::Resize(w, h) = event {
+                if fixed_size.is_some() && w > 0 && h > 0 {
+                    fixed_size = None;
+                    terminal = Terminal::new(CrosstermBackend::new(io::stdout()))?;
+                    terminal.clear()?;
+                }
+            }
+            if let Event::Key(key) = event {
                 // 0. Force Quit (Ctrl+C) - Always available for safety
                 if key.code == KeyCode::Char('c') && key.modifiers.contains(crossterm::event::KeyModifiers::CONTROL) {
                     break;
@@ -377,6 +870,256 @@ async fn main() -> Result<()> {
                     }
 
                 }
+                // 1a. Confirmation Dialog
+                else if app.confirm.is_some() {
+                    match key.code {
+                        KeyCode::Char('y') | KeyCode::Char('Y') => {
+                            if let Some(confirm) = app.confirm.take() {
+                                let action = match confirm.action.is_lifecycle() {
+                                    true => app.queue_action(confirm.action),
+                                    false => Some(confirm.action),
+                                };
+                                if let Some(action) = action {
+                                    let _ = tx_action.send(action).await;
+                                }
+                            }
+                        }
+                        // Enter takes the default the (y/N) prompt advertises
+                        KeyCode::Char('n') | KeyCode::Char('N') | KeyCode::Esc | KeyCode::Enter => {
+                            // A declined restart-and-follow shouldn't reconnect the logs on some later restart
+                            if let Some(app::Confirm { action: Action::Restart(id), .. }) = app.confirm.take() {
+                                if app.pending_log_follow.as_ref() == Some(&id) {
+                                    app.pending_log_follow = None;
+                                }
+                            }
+                        }
+                        _ => {}
+                    }
+                }
+                // 1a'. Kill Signal Menu
+                else if let Some(menu) = &mut app.signal_menu {
+                    let last = app::KILL_SIGNALS.len() - 1;
+                    let choice = match key.code {
+                        KeyCode::Up | KeyCode::Char('k') => { menu.selected = menu.selected.saturating_sub(1); None }
+                        KeyCode::Down | KeyCode::Char('j') => { menu.selected = (menu.selected + 1).min(last); None }
+                        KeyCode::Char(c @ '1'..='4') => Some(c as usize - '1' as usize),
+                        KeyCode::Enter => Some(menu.selected),
+                        KeyCode::Esc | KeyCode::Char('q') => { app.signal_menu = None; None }
+                        _ => None,
+                    };
+                    if let (Some(index), Some(menu)) = (choice, choice.and_then(|_| app.signal_menu.take())) {
+                        let signal = app::KILL_SIGNALS[index].to_string();
+                        if let Some(action) = app.request_action(Action::Kill { id: menu.id, signal: signal.clone() }) {
+                            app.set_action_status(format!("Sending {}...", signal));
+                            let _ = tx_action.send(action).await;
+                        }
+                    }
+                }
+                // 1a''. Prune Menu
+                else if let Some(menu) = &mut app.prune_menu {
+                    match key.code {
+                        KeyCode::Up | KeyCode::Char('k') => menu.selected = 0,
+                        KeyCode::Down | KeyCode::Char('j') => menu.selected = 1,
+                        KeyCode::Char(' ') => menu.toggle(menu.selected),
+                        KeyCode::Char(c @ '1'..='2') => menu.toggle(c as usize - '1' as usize),
+                        KeyCode::Esc | KeyCode::Char('q') => app.prune_menu = None,
+                        KeyCode::Enter => {
+                            if let Some(menu) = app.prune_menu.take() {
+                                let targets = menu.targets(&app.units);
+                                if targets.is_empty() {
+                                    app.set_action_status("Nothing picked to prune".to_string());
+                                } else {
+                                    let note = match &menu.estimate {
+                                        Some(Ok(_)) => "\n\nSizes are estimates from docker system df.",
+                                        _ => "",
+                                    };
+                                    let action = Action::Prune { containers: menu.containers, images: menu.images };
+                                    app.ask_confirm(" Prune ".to_string(), format!("Remove {}?{}\n\n(y/N)", targets.join(" and "), note), action);
+                                }
+                            }
+                        }
+                        _ => {}
+                    }
+                }
+                // 1b. Text Prompt (exec command, watched file, export/save/load paths)
+                else if let Some(prompt) = &mut app.prompt {
+                    match key.code {
+                        KeyCode::Char(c) => prompt.input.push(c),
+                        KeyCode::Backspace => { prompt.input.pop(); }
+                        KeyCode::Esc => app.prompt = None,
+                        KeyCode::Enter => {
+                            let kind = prompt.kind;
+                            let input = prompt.input.trim().to_string();
+                            app.prompt = None;
+                            let selected = app.get_selected_container().map(|c| (c.id.clone(), c.image.clone()));
+                            match (kind, selected) {
+                                (app::PromptKind::LogSearch, _) => app.set_log_search(input),
+                                _ if input.is_empty() => {}
+                                (app::PromptKind::Exec, Some((id, _))) => {
+                                    let client = docker_client.clone();
+                                    let tx = tx_exec_output.clone();
+                                    let cmd = input.clone();
+                                    tokio::spawn(async move {
+                                        let output = match client.exec_command(&id, &cmd).await {
+                                            Ok(o) => o,
+                                            Err(e) => ExecOutput { lines: vec![(true, e.to_string())], exit_code: None },
+                                        };
+                                        let _ = tx.send(output).await;
+                                    });
+                                    app.exec_view = Some(app::ExecView {
+                                        command: input,
+                                        lines: Vec::new(),
+                                        exit_code: None,
+                                        finished: false,
+                                        scroll: 0,
+                                    });
+                                }
+                                (app::PromptKind::WatchFile, Some((id, _))) => {
+                                    app.reset_logs();
+                                    app.watching_file = Some(input.clone());
+                                    let _ = tx_watch_file.send(Some((id, input)));
+                                }
+                                (app::PromptKind::Export, Some((id, _))) => {
+                                    let _ = tx_action.send(Action::Export { id, dest: input.into() }).await;
+                                }
+                                (app::PromptKind::SaveImage, Some((_, image))) => {
+                                    let _ = tx_action.send(Action::SaveImage { reference: image, dest: input.into() }).await;
+                                }
+                                (app::PromptKind::Rename, Some((id, _))) => {
+                                    let running = app.get_selected_container().map(|c| c.state == "running").unwrap_or(false);
+                                    app.pending_rename = Some((id.clone(), running));
+                                    let _ = tx_action.send(Action::Rename { id, name: input }).await;
+                                }
+                                (app::PromptKind::LoadImage, _) => {
+                                    let _ = tx_action.send(Action::LoadImage { src: input.into() }).await;
+                                }
+                                _ => {}
+                            }
+                        }
+                        _ => {}
+                    }
+                }
+                // 1c. Exec Output Overlay
+                else if let Some(view) = &mut app.exec_view {
+                    let max_scroll = view.lines.len().saturating_sub(1);
+                    match key.code {
+                        KeyCode::Up | KeyCode::Char('k') => view.scroll = view.scroll.saturating_sub(1),
+                        KeyCode::Down | KeyCode::Char('j') => view.scroll = (view.scroll + 1).min(max_scroll),
+                        KeyCode::PageUp => view.scroll = view.scroll.saturating_sub(10),
+                        KeyCode::PageDown => view.scroll = (view.scroll + 10).min(max_scroll),
+                        KeyCode::Esc | KeyCode::Char('q') => app.exec_view = None,
+                        _ => {}
+                    }
+                }
+                // 1c''. Inspect JSON Overlay
+                else if let Some(view) = &mut app.inspect_view {
+                    let max_scroll = view.max_scroll();
+                    let page = view.height.max(1);
+                    match key.code {
+                        KeyCode::Up | KeyCode::Char('k') => view.scroll = view.scroll.saturating_sub(1),
+                        KeyCode::Down | KeyCode::Char('j') => view.scroll = (view.scroll + 1).min(max_scroll),
+                        KeyCode::PageUp => view.scroll = view.scroll.saturating_sub(page),
+                        KeyCode::PageDown => view.scroll = (view.scroll + page).min(max_scroll),
+                        KeyCode::Home => view.scroll = 0,
+                        KeyCode::End => view.scroll = max_scroll,
+                        KeyCode::Esc | KeyCode::Char('q') => app.inspect_view = None,
+                        _ if keys::key_matches(key, &app.config.keys.inspect) => app.inspect_view = None,
+                        _ => {}
+                    }
+                }
+                // 1c'. Last Error Overlay
+                else if let Some(scroll) = &mut app.error_view {
+                    // Stops with the last message line at the top instead of scrolling into empty space
+                    // (the header takes three lines)
+                    let max_scroll = app.last_error.as_ref().map(|e| e.message.lines().count() + 2).unwrap_or(0) as u16;
+                    match key.code {
+                        KeyCode::Up | KeyCode::Char('k') => *scroll = scroll.saturating_sub(1),
+                        KeyCode::Down | KeyCode::Char('j') => *scroll = scroll.saturating_add(1).min(max_scroll),
+                        KeyCode::PageUp => *scroll = scroll.saturating_sub(10),
+                        KeyCode::PageDown => *scroll = scroll.saturating_add(10).min(max_scroll),
+                        KeyCode::Esc | KeyCode::Char('q') => app.error_view = None,
+                        _ if keys::key_matches(key, &app.config.keys.show_last_error) => app.error_view = None,
+                        _ => {}
+                    }
+                }
+                // 1d. Daemon Events View
+                else if app.events_view.is_some() {
+                    let total = app.filtered_events().len();
+                    if let Some(view) = &mut app.events_view {
+                        let current = view.scroll.unwrap_or(total.saturating_sub(1));
+                        match key.code {
+                            KeyCode::Up | KeyCode::Char('k') => view.scroll = Some(current.saturating_sub(1)),
+                            KeyCode::Down | KeyCode::Char('j') => view.scroll = Some((current + 1).min(total.saturating_sub(1))),
+                            KeyCode::PageUp => view.scroll = Some(current.saturating_sub(10)),
+                            KeyCode::PageDown => view.scroll = Some((current + 10).min(total.saturating_sub(1))),
+                            KeyCode::End | KeyCode::Char('G') => view.scroll = None,
+                            KeyCode::Tab | KeyCode::Char('t') => {
+                                view.filter = (view.filter + 1) % app::EVENT_TYPES.len();
+                                view.scroll = None;
+                            }
+                            KeyCode::Esc | KeyCode::Char('q') => app.events_view = None,
+                            _ => {}
+                        }
+                    }
+                }
+                // 1e. Compare View
+                else if app.compare_view.is_some() {
+                    if matches!(key.code, KeyCode::Esc | KeyCode::Char('q')) || keys::key_matches(key, &app.config.keys.compare) {
+                        app.compare_view = None;
+                        let _ = tx_compare.send(Vec::new());
+                    }
+                }
+                // 1f. Images View (in place of the container list, so it takes the list keys)
+                else if app.images_view.is_some() {
+                    let refresh = keys::key_matches(key, &app.config.keys.refresh);
+                    let close = matches!(key.code, KeyCode::Esc | KeyCode::Char('q')) || keys::key_matches(key, &app.config.keys.toggle_images);
+                    if let Some(view) = &mut app.images_view {
+                        let last = view.images.as_ref().map(|i| i.len().saturating_sub(1)).unwrap_or(0);
+                        match key.code {
+                            _ if close => app.images_view = None,
+                            _ if refresh => {
+                                view.images = None;
+                                view.error = None;
+                                fetch_images(&docker_client, &tx_images);
+                            }
+                            KeyCode::Up | KeyCode::Char('k') => view.selected = view.selected.saturating_sub(1),
+                            KeyCode::Down | KeyCode::Char('j') => view.selected = (view.selected + 1).min(last),
+                            KeyCode::PageUp => view.selected = view.selected.saturating_sub(10),
+                            KeyCode::PageDown => view.selected = (view.selected + 10).min(last),
+                            KeyCode::Home | KeyCode::Char('g') => view.selected = 0,
+                            KeyCode::End | KeyCode::Char('G') => view.selected = last,
+                            KeyCode::Char('d') | KeyCode::Delete => {
+                                if let Some(image) = view.images.as_ref().and_then(|i| i.get(view.selected)) {
+                                    let (id, label) = (image.id.clone(), image.label());
+                                    app.pending_image_removal = Some((id.clone(), label.clone()));
+                                    let action = Action::RemoveImage { id, label: label.clone(), force: false };
+                                    app.ask_confirm(" Remove image ".to_string(), format!("Remove image {}?\n\n(y/N)", label), action);
+                                }
+                            }
+                            _ => {}
+                        }
+                    }
+                }
+                // 1g. Key Help: only the keys that close it, so q doesn't quit from underneath
+                else if app.show_help {
+                    if matches!(key.code, KeyCode::Esc | KeyCode::Char('q')) || keys::key_matches(key, &app.config.keys.toggle_help) {
+                        app.show_help = false;
+                    }
+                }
+                // 1h. Env filter input: ahead of the global hotkeys so every character is typed, not run
+                else if app.is_typing_env_filter {
+                    // Same editing keys as the container filter
+                    match key.code {
+                        KeyCode::Char(c) => app.env_filter.push(c),
+                        KeyCode::Backspace => { app.env_filter.pop(); }
+                        KeyCode::Enter => app.is_typing_env_filter = false,
+                        KeyCode::Esc => {
+                            app.is_typing_env_filter = false;
+                            app.env_filter.clear();
+                        }
+                        _ => {}
+                    }
+                }
                 // 2. Global Hotkeys (Only when Wizard is CLOSED)
                 else if keys::key_matches(key, &app.config.keys.quit) {
                     break;
@@ -384,32 +1127,103 @@ async fn main() -> Result<()> {
                     let _ = tx_action.send(Action::RefreshContainers).await;
                 } else if keys::key_matches(key, &app.config.keys.toggle_wizard) {
                     app.toggle_wizard();
+                } else if keys::key_matches(key, &app.config.keys.toggle_stats_strip) && !app.is_typing_filter {
+                    app.show_stats_strip = !app.show_stats_strip;
+                    if !app.wants_usage_stats() {
+                        app.strip_stats.clear();
+                        app.strip_missing.clear();
+                    }
+                    let _ = tx_strip.send(app.wants_usage_stats());
+                } else if keys::key_matches(key, &app.config.keys.cycle_sort) && !app.is_typing_filter {
+                    app.cycle_sort();
+                    let _ = tx_strip.send(app.wants_usage_stats());
+                    app.refilter();
+                    retarget(&app, &tx_target);
+                    let note = if app.sorts_by_usage() && app.strip_stats.is_empty() { " (sampling stats…)" } else { "" };
+                    app.set_action_status(format!("Sort: {}{}", app.sort, note));
+                } else if keys::key_matches(key, &app.config.keys.toggle_cpu_colors) && !app.is_typing_filter {
+                    app.relative_cpu_colors = !app.relative_cpu_colors;
+                    let mode = if app.relative_cpu_colors { "relative to the busiest container" } else { "absolute thresholds" };
+                    app.set_action_status(format!("CPU colors: {}", mode));
+                } else if keys::key_matches(key, &app.config.keys.toggle_images) && !app.is_typing_filter {
+                    app.images_view = Some(app::ImagesView { images: None, error: None, selected: 0 });
+                    fetch_images(&docker_client, &tx_images);
+                } else if keys::key_matches(key, &app.config.keys.prune) && !app.is_typing_filter {
+                    app.prune_menu = Some(app::PruneMenu::new());
+                    let client = docker_client.clone();
+                    let tx = tx_prune_estimate.clone();
+                    tokio::spawn(async move {
+                        let _ = tx.send(client.prune_estimate().await.map_err(|e| e.to_string())).await;
+                    });
+                } else if keys::key_matches(key, &app.config.keys.toggle_pause) && !app.is_typing_filter {
+                    app.refresh_paused = !app.refresh_paused;
+                    if !app.refresh_paused {
+                        // Lists that arrived while paused were dropped, so fetch a current one right away
+                        let _ = tx_action.send(Action::RefreshContainers).await;
+                        if app.sorts_by_usage() {
+                            app.refilter();
+                            retarget(&app, &tx_target);
+                        }
+                    }
+                } else if keys::key_matches(key, &app.config.keys.toggle_zen) && !app.is_typing_filter {
+                    app.zen = !app.zen;
+                    if app.zen {
+                        // The footer that lists the keys is gone, so say once how to get it back
+                        app.set_action_status(format!("Header and footer hidden, [{}] to restore", app.config.keys.toggle_zen));
+                    }
+                } else if keys::key_matches(key, &app.config.keys.toggle_ids) && !app.is_typing_filter {
+                    app.show_ids = !app.show_ids;
+                } else if keys::key_matches(key, &app.config.keys.show_last_error) && !app.is_typing_filter {
+                    if app.last_error.is_some() {
+                        app.error_view = Some(0);
+                    } else {
+                        app.set_action_status("No errors so far".to_string());
+                    }
+                } else if keys::key_matches(key, &app.config.keys.toggle_events) && !app.is_typing_filter {
+                    app.events_view = Some(app::EventsView { filter: 0, scroll: None });
                 } else if keys::key_matches(key, "c") || keys::key_matches(key, "Tab") {
                      app.toggle_wizard();
                 } else if keys::key_matches(key, "Esc") {
                     if app.is_typing_filter {
                         app.is_typing_filter = false;
                         app.filter_query.clear();
-                    } else if app.show_help {
-                        app.show_help = false;
+                        app.refilter();
+                        retarget(&app, &tx_target);
+                    } else if !app.batch.is_empty() {
+                        app.batch.clear();
+                        app.set_action_status("Batch cleared".to_string());
                     }
                 } else if app.is_typing_filter {
                     // Handle typing
                     match key.code {
                         KeyCode::Char(c) => {
                             app.filter_query.push(c);
+                            app.refilter();
+                            retarget(&app, &tx_target);
                         }
                         KeyCode::Backspace => {
                             app.filter_query.pop();
+                            app.refilter();
+                            retarget(&app, &tx_target);
                         }
                         KeyCode::Enter => {
                             app.is_typing_filter = false;
+                            // In highlight mode, land on the first match if we aren't on one already
+                            let on_match = app.get_selected_container().map(|c| app.matches_filter(c)).unwrap_or(false);
+                            if app.is_highlight_filter() && !on_match {
+                                app.next_match();
+                                if let Some(c) = app.get_selected_container() {
+                                    let _ = tx_target.send(Some(c.id.clone()));
+                                }
+                            }
                         }
                         _ => {}
                     }
                 } else if keys::key_matches(key, "/") {
                     app.is_typing_filter = true;
                     app.filter_query.clear();
+                    app.refilter();
+                    retarget(&app, &tx_target);
                 } else if keys::key_matches(key, &app.config.keys.toggle_help) {
                     app.show_help = !app.show_help;
                 } else {
@@ -421,18 +1235,46 @@ async fn main() -> Result<()> {
                             if let Some(c) = app.get_selected_container() {
                                 let _ = tx_target.send(Some(c.id.clone()));
                             }
+                        } else if keys::key_matches(key, &app.config.keys.delete) && !app.batch.is_empty() {
+                            if let Some(action) = app.request_batch(Action::Delete) {
+                                let _ = tx_action.send(action).await;
+                            }
                         } else if keys::key_matches(key, &app.config.keys.delete) {
                             if let Some(c) = app.get_selected_container() {
-                                let _ = tx_action.send(Action::Delete(c.id.clone())).await;
+                                if let Some(action) = app.request_action(Action::Delete(c.id.clone())) {
+                                    let _ = tx_action.send(action).await;
+                                }
                             }
                         } else if keys::key_matches(key, &app.config.keys.down) {
                             app.next();
+                            retarget(&app, &tx_target);
+                        } else if keys::key_matches(key, &app.config.keys.up) {
+                            app.previous();
+                            retarget(&app, &tx_target);
+                        } else if keys::key_matches(key, &app.config.keys.next_problem) {
+                            match app.next_problem() {
+                                Some(problem) => {
+                                    if let Some(c) = app.get_selected_container() {
+                                        let name = c.names.first().map(|n| n.trim_start_matches('/').to_string()).unwrap_or_default();
+                                        let _ = tx_target.send(Some(c.id.clone()));
+                                        app.set_action_status(format!("{}: {}", name, problem));
+                                    }
+                                }
+                                None => app.set_action_status("No problem containers".to_string()),
+                            }
+                        } else if !app.log_search.is_empty() && keys::key_matches(key, &app.config.keys.next_match) {
+                            // A log search takes n/N over from the container filter until it is cleared
+                            app.step_log_match(true);
+                        } else if !app.log_search.is_empty() && keys::key_matches(key, &app.config.keys.prev_match) {
+                            app.step_log_match(false);
+                        } else if !app.filter_query.is_empty() && keys::key_matches(key, &app.config.keys.next_match) {
+                            app.next_match();
                             if let Some(c) = app.get_selected_container() {
                                 let _ = tx_target.send(Some(c.id.clone()));
                             }
-                        } else if keys::key_matches(key, &app.config.keys.up) {
-                             app.previous();
-                             if let Some(c) = app.get_selected_container() {
+                        } else if !app.filter_query.is_empty() && keys::key_matches(key, &app.config.keys.prev_match) {
+                            app.previous_match();
+                            if let Some(c) = app.get_selected_container() {
                                 let _ = tx_target.send(Some(c.id.clone()));
                             }
                         } else if keys::key_matches(key, &app.config.keys.edit) {
@@ -518,9 +1360,178 @@ async fn main() -> Result<()> {
                              if let Some(container) = app.get_selected_container() {
                                 let id = container.id.clone();
                                 let cli_path = app.config.general.docker_cli_path.clone();
-                                let _ = enter_container_shell(&id, &mut terminal, &cli_path);
+                                if container.state != "running" {
+                                    // `docker exec` would only flash its error before the TUI comes back
+                                    app.set_action_status(format!("Failed to open shell: {} is not running", app.container_label(container)));
+                                } else if let Ok(Some(msg)) = enter_container_shell(&id, &mut terminal, &cli_path) {
+                                    app.set_action_status(msg);
+                                }
                                 terminal.clear()?;
                             }
+                        } else if keys::key_matches(key, &app.config.keys.exec_command) {
+                            if app.get_selected_container().is_some() {
+                                app.prompt = Some(app::Prompt::new(app::PromptKind::Exec, String::new()));
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.watch_file) {
+                            if app.watching_file.is_some() {
+                                // Back to regular container logs
+                                app.reset_logs();
+                                app.watching_file = None;
+                                let _ = tx_watch_file.send(None);
+                            } else if app.get_selected_container().is_some() {
+                                app.prompt = Some(app::Prompt::new(app::PromptKind::WatchFile, String::new()));
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.toggle_line_numbers) {
+                            app.show_line_numbers = !app.show_line_numbers;
+                        } else if keys::key_matches(key, &app.config.keys.search_logs) {
+                            app.prompt = Some(app::Prompt::new(app::PromptKind::LogSearch, app.log_search.clone()));
+                        } else if keys::key_matches(key, &app.config.keys.toggle_log_wrap) {
+                            app.log_wrap = !app.log_wrap;
+                        } else if keys::key_matches(key, &app.config.keys.copy_inspect) {
+                            if let Some(c) = app.get_selected_container() {
+                                let id = c.id.clone();
+                                let client = docker_client.clone();
+                                let tx = tx_inspect_json.clone();
+                                tokio::spawn(async move {
+                                    let json = client.inspect_container_json(&id).await;
+                                    let _ = tx.send((id, json)).await;
+                                });
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.inspect) {
+                            if let Some(c) = app.get_selected_container() {
+                                let id = c.id.clone();
+                                app.inspect_view = Some(app::InspectView { id: id.clone(), lines: None, scroll: 0, height: 0 });
+                                let client = docker_client.clone();
+                                let tx = tx_inspect_view.clone();
+                                tokio::spawn(async move {
+                                    let json = client.inspect_container_json(&id).await;
+                                    let _ = tx.send((id, json)).await;
+                                });
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.filter_env) {
+                            if app.show_details || app.env_view {
+                                app.is_typing_env_filter = true;
+                                app.env_filter.clear();
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.shrink_list) || keys::key_matches(key, &app.config.keys.grow_list) {
+                            let step = config::PANEL_RESIZE_STEP;
+                            app.list_width = if keys::key_matches(key, &app.config.keys.grow_list) {
+                                (app.list_width + step).min(config::MAX_LIST_WIDTH)
+                            } else {
+                                app.list_width.saturating_sub(step).max(config::MIN_LIST_WIDTH)
+                            };
+                            let mut state = config::UiState::load();
+                            state.list_width = Some(app.list_width);
+                            let saved = state.save();
+                            let status = match (app.config.general.layout.as_str(), saved) {
+                                ("logs" | "list", _) => format!("List width {}% (applies to the default layout)", app.list_width),
+                                (_, Ok(())) => format!("List width {}%", app.list_width),
+                                (_, Err(e)) => format!("List width {}% (not saved: {})", app.list_width, e),
+                            };
+                            app.set_action_status(status);
+                        } else if keys::key_matches(key, &app.config.keys.shrink_logs) || keys::key_matches(key, &app.config.keys.grow_logs) {
+                            let step = config::PANEL_RESIZE_STEP;
+                            app.logs_height = if keys::key_matches(key, &app.config.keys.grow_logs) {
+                                (app.logs_height + step).min(config::MAX_LOGS_HEIGHT)
+                            } else {
+                                app.logs_height.saturating_sub(step).max(config::MIN_LOGS_HEIGHT)
+                            };
+                            let mut state = config::UiState::load();
+                            state.logs_height = Some(app.logs_height);
+                            let saved = state.save();
+                            let status = match (app.config.general.layout.as_str(), saved) {
+                                ("logs" | "list", _) => format!("Logs height {}% (applies to the default layout)", app.logs_height),
+                                (_, Ok(())) => format!("Logs height {}%", app.logs_height),
+                                (_, Err(e)) => format!("Logs height {}% (not saved: {})", app.logs_height, e),
+                            };
+                            app.set_action_status(status);
+                        } else if keys::key_matches(key, &app.config.keys.toggle_env) {
+                            app.env_view = !app.env_view;
+                            app.env_scroll = 0;
+                        } else if keys::key_matches(key, &app.config.keys.reveal_env) {
+                            app.reveal_env = !app.reveal_env;
+                            let state = if app.reveal_env { "revealed" } else { "masked" };
+                            app.set_action_status(format!("Secret env values {}", state));
+                        } else if app.env_view && matches!(key.code, KeyCode::PageUp | KeyCode::PageDown | KeyCode::Home | KeyCode::End) {
+                            // The open env list takes the paging keys from the logs
+                            let last = app.filtered_env().len().saturating_sub(app.env_area_height);
+                            let top = app.env_scroll.min(last);
+                            let page = app.env_area_height.max(1);
+                            app.env_scroll = match key.code {
+                                KeyCode::PageUp => top.saturating_sub(page),
+                                KeyCode::PageDown => (top + page).min(last),
+                                KeyCode::Home => 0,
+                                _ => last,
+                            };
+                        } else if keys::key_matches(key, &app.config.keys.dump_stats) {
+                            if let Some(c) = app.get_selected_container() {
+                                let id = c.id.clone();
+                                let shown_cpu = app.current_stats.as_ref().map(|s| ui::calculate_cpu_usage(s, &app.previous_stats));
+                                let _ = tx_action.send(Action::DumpStats { id, shown_cpu }).await;
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.save_logs) {
+                            if let Some(c) = app.get_selected_container() {
+                                let name = c.names.first().cloned().unwrap_or_else(|| c.id.clone());
+                                let _ = tx_action.send(Action::SaveLogs { id: c.id.clone(), name }).await;
+                                app.set_action_status("Saving logs...".to_string());
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.copy_exec) {
+                            if let Some(c) = app.get_selected_container() {
+                                let target = c.names.first().map(|n| n.trim_start_matches('/').to_string()).unwrap_or_else(|| c.id.chars().take(12).collect());
+                                let command = format!("docker exec -it {} {}", target, app.config.general.exec_shell);
+                                let status = match clipboard::copy_or_save(&command, "/tmp/docktop-exec.txt") {
+                                    Ok(clipboard::CopyResult::Clipboard) => format!("Copied: {}", command),
+                                    Ok(clipboard::CopyResult::File(path)) => format!("Exec command saved to {}", path),
+                                    Err(e) => format!("Copy failed: {}", e),
+                                };
+                                app.set_action_status(status);
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.copy_id) {
+                            if let Some(c) = app.get_selected_container() {
+                                // The status carries the full ID either way, so it can be read off
+                                // the screen where the terminal ignores OSC52 (e.g. over some SSH setups)
+                                let status = match clipboard::copy_or_save(&c.id, "/tmp/docktop-id.txt") {
+                                    Ok(_) => format!("Copied {}", c.id),
+                                    Err(e) => format!("Copy failed ({}), ID: {}", e, c.id),
+                                };
+                                app.set_action_status(status);
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.copy_logs) {
+                            let lines = ui::logs::visible_lines(&app);
+                            if lines.is_empty() {
+                                app.set_action_status("No log lines to copy".to_string());
+                            } else {
+                                let count = lines.len();
+                                let status = match clipboard::copy_or_save(&lines.join("\n"), "/tmp/docktop-logs.txt") {
+                                    Ok(clipboard::CopyResult::Clipboard) => format!("Copied {} visible log lines", count),
+                                    Ok(clipboard::CopyResult::File(path)) => format!("Log lines too large for clipboard, saved to {}", path),
+                                    Err(e) => format!("Copy failed: {}", e),
+                                };
+                                app.set_action_status(status);
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.export) {
+                            if let Some(c) = app.get_selected_container() {
+                                let name = c.names.first().map(|n| n.trim_start_matches('/').to_string()).unwrap_or_else(|| c.id.chars().take(12).collect());
+                                app.prompt = Some(app::Prompt::new(app::PromptKind::Export, format!("./{}.tar", name)));
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.save_image) {
+                            if let Some(c) = app.get_selected_container() {
+                                let file = c.image.replace(['/', ':'], "_");
+                                app.prompt = Some(app::Prompt::new(app::PromptKind::SaveImage, format!("./{}.tar", file)));
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.load_image) {
+                            app.prompt = Some(app::Prompt::new(app::PromptKind::LoadImage, String::new()));
+                        } else if keys::key_matches(key, &app.config.keys.toggle_log_mode) {
+                            app.log_mode = match app.log_mode {
+                                LogMode::Stream => LogMode::Poll,
+                                LogMode::Poll | LogMode::Tail => LogMode::Stream,
+                            };
+                            app.reset_logs();
+                            let _ = tx_log_mode.send(app.log_mode);
+                        } else if keys::key_matches(key, &app.config.keys.cycle_log_refresh) {
+                            let _ = tx_log_refresh.send(app.cycle_log_refresh());
+                            let note = if app.active_log_mode == LogMode::Stream { " (applies when polling)" } else { "" };
+                            app.set_action_status(format!("Log refresh: {}{}", app.log_refresh_label(), note));
                         } else if keys::key_matches(key, &app.config.keys.db_cli) {
                              if let Some(container) = app.get_selected_container() {
                                 let image = container.image.to_lowercase();
@@ -531,23 +1542,128 @@ async fn main() -> Result<()> {
                                     terminal.clear()?;
                                 }
                             }
+                        } else if keys::key_matches(key, &app.config.keys.restart_follow) {
+                            if let Some(c) = app.get_selected_container() {
+                                let id = c.id.clone();
+                                app.set_action_status("Restarting, logs will follow...".to_string());
+                                // Make sure the logs panel is visible and tailing the newest lines
+                                app.exec_view = None;
+                                app.events_view = None;
+                                app.log_follow = true;
+                                app.pending_log_follow = Some(id.clone());
+                                if let Some(action) = app.request_action(Action::Restart(id)) {
+                                    let _ = tx_action.send(action).await;
+                                }
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.rename) {
+                            if let Some(c) = app.get_selected_container() {
+                                let name = c.names.first().map(|n| n.trim_start_matches('/').to_string()).unwrap_or_default();
+                                app.prompt = Some(app::Prompt::new(app::PromptKind::Rename, name));
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.mark) {
+                            app.toggle_mark();
+                        } else if keys::key_matches(key, &app.config.keys.batch_select) {
+                            app.toggle_batch();
+                        } else if keys::key_matches(key, &app.config.keys.toggle_groups) {
+                            app.group_by_project = !app.group_by_project;
+                            app.refilter();
+                            retarget(&app, &tx_target);
+                            let mode = if app.group_by_project { "Grouped by Compose project" } else { "Ungrouped" };
+                            app.set_action_status(mode.to_string());
+                        } else if keys::key_matches(key, &app.config.keys.collapse_group) {
+                            app.collapse_selected_group();
+                            retarget(&app, &tx_target);
+                        } else if keys::key_matches(key, &app.config.keys.expand_groups) {
+                            app.collapsed_groups.clear();
+                            app.refilter();
+                            retarget(&app, &tx_target);
+                        } else if keys::key_matches(key, &app.config.keys.compare) {
+                            if app.marked.len() == 2 {
+                                let ids = [app.marked[0].clone(), app.marked[1].clone()];
+                                let _ = tx_compare.send(ids.to_vec());
+                                app.compare_view = Some(app::CompareView::new(ids));
+                            } else {
+                                app.set_action_status(format!("Mark two containers with [{}] to compare", app.config.keys.mark));
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.toggle_log_follow) {
+                            if app.active_log_mode == LogMode::Tail {
+                                app.set_action_status("Following is unavailable for stopped containers".to_string());
+                            } else {
+                                app.log_follow = !app.log_follow;
+                                app.log_anchor = None;
+                                app.log_scroll = 0;
+                            }
+                        } else if matches!(key.code, KeyCode::PageUp | KeyCode::PageDown | KeyCode::Home | KeyCode::End) {
+                            // Pages through the logs from wherever they are shown now, following included
+                            let (width, height) = app.log_area;
+                            let last = ui::logs::last_page_start(&app, width, height);
+                            let top = if app.log_follow { last } else { app.log_scroll.min(last) };
+                            let page = height.max(1);
+                            match key.code {
+                                KeyCode::PageUp => app.log_scroll = top.saturating_sub(page),
+                                KeyCode::PageDown => app.log_scroll = (top + page).min(last),
+                                KeyCode::Home => app.log_scroll = 0,
+                                _ => app.log_scroll = last,
+                            }
+                            // End picks following back up where it is available
+                            app.log_follow = key.code == KeyCode::End && app.active_log_mode != LogMode::Tail;
+                        } else if keys::key_matches(key, &app.config.keys.restart) && app.selected_group.is_some() {
+                            if let Some(action) = app.request_project(Action::Restart) {
+                                app.set_action_status(format!("Restarting {} containers...", action.lifecycle_targets().len()));
+                                let _ = tx_action.send(action).await;
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.restart) && !app.batch.is_empty() {
+                            if let Some(action) = app.request_batch(Action::Restart) {
+                                app.set_action_status(format!("Restarting {} containers...", app.batch.len()));
+                                let _ = tx_action.send(action).await;
+                            }
                         } else if keys::key_matches(key, &app.config.keys.restart) {
                             if let Some(c) = app.get_selected_container() {
                                 let id = c.id.clone();
-                                app.set_action_status("Restarting...".to_string());
-                                let _ = tx_action.send(Action::Restart(id)).await;
+                                if let Some(action) = app.request_action(Action::Restart(id)) {
+                                    app.set_action_status("Restarting...".to_string());
+                                    let _ = tx_action.send(action).await;
+                                }
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.stop) && app.selected_group.is_some() {
+                            if let Some(action) = app.request_project(Action::Stop) {
+                                app.set_action_status(format!("Stopping {} containers...", action.lifecycle_targets().len()));
+                                let _ = tx_action.send(action).await;
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.stop) && !app.batch.is_empty() {
+                            if let Some(action) = app.request_batch(Action::Stop) {
+                                app.set_action_status(format!("Stopping {} containers...", app.batch.len()));
+                                let _ = tx_action.send(action).await;
                             }
                         } else if keys::key_matches(key, &app.config.keys.stop) {
                             if let Some(c) = app.get_selected_container() {
                                 let id = c.id.clone();
-                                app.set_action_status("Stopping...".to_string());
-                                let _ = tx_action.send(Action::Stop(id)).await;
+                                if let Some(action) = app.request_action(Action::Stop(id)) {
+                                    app.set_action_status("Stopping...".to_string());
+                                    let _ = tx_action.send(action).await;
+                                }
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.kill) {
+                            if let Some(c) = app.get_selected_container() {
+                                app.signal_menu = Some(app::SignalMenu { id: c.id.clone(), selected: 0 });
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.start) && app.selected_group.is_some() {
+                            if let Some(action) = app.request_project(Action::Start) {
+                                app.set_action_status(format!("Starting {} containers...", action.lifecycle_targets().len()));
+                                let _ = tx_action.send(action).await;
+                            }
+                        } else if keys::key_matches(key, &app.config.keys.start) && !app.batch.is_empty() {
+                            if let Some(action) = app.request_batch(Action::Start) {
+                                app.set_action_status(format!("Starting {} containers...", app.batch.len()));
+                                let _ = tx_action.send(action).await;
                             }
                         } else if keys::key_matches(key, &app.config.keys.start) {
                             if let Some(c) = app.get_selected_container() {
                                 let id = c.id.clone();
-                                app.set_action_status("Starting...".to_string());
-                                let _ = tx_action.send(Action::Start(id)).await;
+                                if let Some(action) = app.request_action(Action::Start(id)) {
+                                    app.set_action_status("Starting...".to_string());
+                                    let _ = tx_action.send(action).await;
+                                }
                             }
                         } else if keys::key_matches(key, &app.config.keys.yaml) {
                              if let Some(c) = app.get_selected_container() {
@@ -679,24 +1795,152 @@ async fn main() -> Result<()> {
         if last_tick.elapsed() >= tick_rate {
             // Update Containers
             while let Ok(containers) = rx_containers.try_recv() {
+                if app.refresh_paused {
+                    continue;
+                }
                 app.update_containers(containers);
                 // If selection out of bounds, reset
                 if app.selected_index >= app.containers.len() && !app.containers.is_empty() {
                     app.selected_index = app.containers.len() - 1;
                 }
-                if app.containers.len() > 0 && rx_target.borrow().is_none() {
-                     if let Some(c) = app.get_selected_container() {
-                        let _ = tx_target.send(Some(c.id.clone()));
+                if app.follow_newest {
+                    if let Some(id) = app.select_newest() {
+                        let _ = tx_target.send(Some(id));
+                    }
+                    app.log_follow = true;
+                }
+                // Also moves the target off a container that was just removed
+                retarget(&app, &tx_target);
+            }
+
+            if rx_daemon_down.has_changed().unwrap_or(false) {
+                app.daemon_down = rx_daemon_down.borrow_and_update().clone();
+            }
+
+            // Update Runtime Info
+            if let Ok(info) = rx_server_info.try_recv() {
+                app.server_info = Some(info);
+            }
+
+            // Update Log Status (the streamer reports connects, fallbacks to polling and failures)
+            while let Ok(status) = rx_log_status.try_recv() {
+                match &status {
+                    LogStatus::Connected(mode) => app.active_log_mode = *mode,
+                    LogStatus::Failed(e) => app.record_error("Fetching logs", e),
+                    LogStatus::Loading | LogStatus::Gone => {}
+                }
+                app.log_status = status;
+            }
+
+            // Update Security Audit
+            while let Ok(flags) = rx_privileged.try_recv() {
+                app.privileged = flags;
+            }
+
+            while let Ok(counts) = rx_restart_counts.try_recv() {
+                app.update_restart_counts(counts);
+            }
+
+            // Update Volume Usage
+            while let Ok(sizes) = rx_volume_sizes.try_recv() {
+                app.volume_sizes = sizes;
+            }
+
+            // Update Compare View
+            let mut usage_changed = false;
+            while let Ok((id, stats)) = rx_strip_stats.try_recv() {
+                app.update_strip_stats(id, stats);
+                usage_changed = true;
+            }
+            if usage_changed && app.sorts_by_usage() && !app.refresh_paused {
+                app.refilter();
+                retarget(&app, &tx_target);
+            }
+
+            while let Ok(result) = rx_prune_estimate.try_recv() {
+                if let Some(menu) = &mut app.prune_menu {
+                    menu.estimate = Some(result);
+                }
+            }
+
+            while let Ok(result) = rx_images.try_recv() {
+                if let Some(view) = &mut app.images_view {
+                    match result {
+                        Ok(images) => {
+                            // Like the container list, the cursor stays on the same image when a
+                            // refetch (after a load or prune) reorders the newest-first list
+                            let previous = view.images.as_ref().and_then(|i| i.get(view.selected)).map(|i| i.id.clone());
+                            view.selected = match previous.and_then(|id| images.iter().position(|i| i.id == id)) {
+                                Some(idx) => idx,
+                                None => view.selected.min(images.len().saturating_sub(1)),
+                            };
+                            view.images = Some(images);
+                        }
+                        Err(e) => {
+                            view.error = Some(e.clone());
+                            app.record_error("Listing images", &e);
+                        }
                     }
                 }
             }
 
+            while let Ok((id, stats)) = rx_compare_stats.try_recv() {
+                let history_size = app.config.general.graphs_history_size;
+                let paused = app.is_paused(&id);
+                if let Some(view) = &mut app.compare_view {
+                    view.update(&id, stats, paused, history_size);
+                }
+            }
+
+            // Update Daemon Events
+            while let Ok(event) = rx_events.try_recv() {
+                app.add_event(event);
+            }
+
+            // Update Exec Output
+            while let Ok(output) = rx_exec_output.try_recv() {
+                if let Some(view) = &mut app.exec_view {
+                    view.lines = output.lines;
+                    view.exit_code = output.exit_code;
+                    view.finished = true;
+                }
+            }
+
+            // Copy Inspect JSON (written from here so the escape sequence doesn't interleave with a draw)
+            while let Ok((id, json)) = rx_inspect_json.try_recv() {
+                let status = match json {
+                    Ok(json) => {
+                        let short_id: String = id.chars().take(12).collect();
+                        let fallback = format!("/tmp/docktop_inspect_{}.json", short_id);
+                        match clipboard::copy_or_save(&json, &fallback) {
+                            Ok(clipboard::CopyResult::Clipboard) => "Inspect JSON copied to clipboard".to_string(),
+                            Ok(clipboard::CopyResult::File(path)) => format!("Inspect JSON too large for clipboard, saved to {}", path),
+                            Err(e) => format!("Copy failed: {}", e),
+                        }
+                    }
+                    Err(e) => format!("Inspect failed: {}", e),
+                };
+                app.set_action_status(status);
+            }
+
+            while let Ok((id, json)) = rx_inspect_view.try_recv() {
+                if let Some(view) = app.inspect_view.as_mut().filter(|v| v.id == id) {
+                    view.lines = Some(json.map(|j| j.lines().map(|l| l.to_string()).collect()).map_err(|e| e.to_string()));
+                }
+            }
+
             // Update Details
-            while let Ok((stats, inspect)) = rx_details.try_recv() {
+            while let Ok((id, stats, inspect)) = rx_details.try_recv() {
+                // A fetch that finished after the cursor moved on belongs to another container
+                if app.get_selected_container().map(|c| &c.id) != Some(&id) {
+                    continue;
+                }
                 // Store current as previous before updating
                 if let Some(curr) = app.current_stats.take() {
                     app.previous_stats = Some(curr);
                 }
+                let started_at = inspect.as_ref().and_then(|i| i.state.as_ref()).and_then(|s| s.started_at.clone());
+                app.note_run(started_at.as_deref());
                 app.current_stats = stats;
                 app.current_inspection = inspect;
                 app.is_loading_details = false;
@@ -705,41 +1949,17 @@ async fn main() -> Result<()> {
                 // We need to clone stats or extract values to avoid borrowing app twice
                 let (cpu, rx, tx) = if let Some(stats) = &app.current_stats {
                     let cpu = ui::calculate_cpu_usage(stats, &app.previous_stats);
-                    
-                    let (rx, tx) = if let Some(nets) = &stats.networks {
-                        let mut total_rx = 0.0;
-                        let mut total_tx = 0.0;
-                        for (_, net) in nets {
-                            total_rx += net.rx_bytes as f64;
-                            total_tx += net.tx_bytes as f64;
-                        }
-                        
-                        if let Some(prev) = &app.previous_stats {
-                            if let Some(prev_nets) = &prev.networks {
-                                let mut prev_rx = 0.0;
-                                let mut prev_tx = 0.0;
-                                for (_, net) in prev_nets {
-                                    prev_rx += net.rx_bytes as f64;
-                                    prev_tx += net.tx_bytes as f64;
-                                }
-                                (total_rx - prev_rx, total_tx - prev_tx)
-                            } else {
-                                (0.0, 0.0)
-                            }
-                        } else {
-                            (0.0, 0.0)
-                        }
-                    } else {
-                        (0.0, 0.0)
-                    };
-                    
+                    let (rx, tx) = app.previous_stats.as_ref().and_then(|prev| stats.network_rates(prev)).unwrap_or((0.0, 0.0));
                     (Some(cpu), Some(rx), Some(tx))
                 } else {
                     (None, None, None)
                 };
 
-                if let Some(c) = cpu {
+                // Stop the graph while paused rather than plotting the last pre-pause delta
+                let paused = app.get_selected_container().map(|c| c.state == "paused").unwrap_or(false);
+                if let Some(c) = cpu.filter(|_| !paused) {
                     app.update_cpu_history(c);
+                    app.update_container_cpu_history(c);
                 }
                 if let Some(r) = rx {
                     if let Some(t) = tx {
@@ -753,10 +1973,88 @@ async fn main() -> Result<()> {
                 app.add_log(log);
             }
 
-            // Update Action Results
-            if let Ok(msg) = rx_action_result.try_recv() {
-                let is_scan_complete = msg == "Scan Complete";
+            // Update Action Results: progress lines first, so they can't land after their action's result
+            while let Ok(msg) = rx_action_result.try_recv() {
                 app.set_action_status(msg);
+                // The wizard closes on the first word back from what it dispatched
+                if app.wizard.is_some() {
+                    app.toggle_wizard();
+                }
+            }
+            while let Ok(done) = rx_action_done.try_recv() {
+                let msg = done.message.clone();
+                let is_scan_complete = msg == "Scan Complete";
+                if done.notify {
+                    if app.config.general.action_bell {
+                        notify::bell();
+                    }
+                    if app.config.general.enable_notifications {
+                        let title = if notify::is_failure(&msg) { "DockTop: action failed" } else { "DockTop: action done" };
+                        notify::desktop(title, &msg);
+                    }
+                }
+                // Images that are multi-tagged or used by stopped containers need a force; offer it once
+                if let Some((id, label)) = &app.pending_image_removal {
+                    if msg.starts_with(&format!("Image removal failed ({})", label)) {
+                        if msg.ends_with("(must be forced)") {
+                            let action = Action::RemoveImage { id: id.clone(), label: label.clone(), force: true };
+                            let message = format!("{}.\n\nForce-remove {} anyway? (y/N)", msg, label);
+                            app.ask_confirm(" Force remove image ".to_string(), message, action);
+                        }
+                        app.pending_image_removal = None;
+                    } else if msg == format!("Removed image {}", label) {
+                        app.pending_image_removal = None;
+                    }
+                }
+                if (msg.starts_with("Removed image") || msg.starts_with("Pruned")) && app.images_view.is_some() {
+                    fetch_images(&docker_client, &tx_images);
+                }
+                // Rename never restarts on its own; offer it for running containers, whose hostname keeps the old name
+                if let Some((id, was_running)) = &app.pending_rename {
+                    let short_id = &id[..12.min(id.len())];
+                    if msg.starts_with("Renamed container") && msg.contains(short_id) {
+                        if *was_running {
+                            app.ask_confirm(
+                                " Renamed ".to_string(),
+                                format!(
+                                    "{}.\n\nThe container is still running under its old hostname, and tools that cached the old name (compose, proxies, scripts) may keep using it until it restarts.\n\nAlso restart it now? (y/N)",
+                                    msg
+                                ),
+                                Action::Restart(id.clone()),
+                            );
+                        }
+                        app.pending_rename = None;
+                    } else if msg.starts_with("Failed to rename") {
+                        app.pending_rename = None;
+                    }
+                }
+                // Restart-and-follow: the old log stream ended with the restart, so reconnect for the fresh logs
+                if let Some(id) = &app.pending_log_follow {
+                    let short_id = &id[..12.min(id.len())];
+                    // A batch reports one line per container
+                    if msg.lines().any(|l| (l.starts_with("Restarted container") && l.contains(short_id)) || l.starts_with("Failed to restart")) {
+                        app.pending_log_follow = None;
+                        app.reset_logs();
+                        log_generation += 1;
+                        let _ = tx_log_reconnect.send(log_generation);
+                    }
+                }
+                // Queued lifecycle actions: send the next one only now, and show the whole run's results together
+                if app.finishes_in_flight(&done) {
+                    match app.finish_queued_action(&done) {
+                        Some(next) => {
+                            let _ = tx_action.send(next).await;
+                            let waiting = app.action_queue.len();
+                            app.set_action_status(format!("{} ({} more queued)", msg, waiting + 1));
+                        }
+                        None => {
+                            let summary = app.queue_summary();
+                            app.set_action_status(summary);
+                        }
+                    }
+                } else {
+                    app.set_action_status(msg);
+                }
                 // If we receive a result, it means the action is done.
                 // We should close the wizard if it's open.
                 if app.wizard.is_some() {
@@ -781,6 +2079,7 @@ async fn main() -> Result<()> {
             
             app.clear_action_status();
             app.update_fish();
+            app.update_spinner();
             app.update_wizard_spinner();
 
             last_tick = std::time::Instant::now();
@@ -0,0 +1,37 @@
+use std::io::Write;
+use std::process::{Command, Stdio};
+
+pub fn is_failure(msg: &str) -> bool {
+    let lower = msg.to_lowercase();
+    lower.contains("fail") || lower.contains("error")
+}
+
+pub fn bell() {
+    let mut stdout = std::io::stdout();
+    let _ = stdout.write_all(b"\x07");
+    let _ = stdout.flush();
+}
+
+// Desktop notification; silently does nothing if no notifier is installed. The notifier is
+// waited on in the background so it doesn't linger as a zombie.
+pub fn desktop(title: &str, body: &str) {
+    let mut cmd = if cfg!(target_os = "macos") {
+        let script = format!(
+            "display notification \"{}\" with title \"{}\"",
+            body.replace('"', "'"),
+            title.replace('"', "'")
+        );
+        let mut c = Command::new("osascript");
+        c.arg("-e").arg(script);
+        c
+    } else {
+        let mut c = Command::new("notify-send");
+        c.arg(title).arg(body);
+        c
+    };
+    if let Ok(mut child) = cmd.stdin(Stdio::null()).stdout(Stdio::null()).stderr(Stdio::null()).spawn() {
+        std::thread::spawn(move || {
+            let _ = child.wait();
+        });
+    }
+}
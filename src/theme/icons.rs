@@ -92,3 +92,103 @@ impl IconSet {
         Self::CONTAINER
     }
 }
+
+// Glyphs for status indicators and badges, selectable per terminal/font via `symbols` in config
+pub struct Symbols {
+    pub running: &'static str,
+    pub stopped: &'static str,
+    pub warning: &'static str,
+    pub privileged: &'static str,
+    pub marked: &'static str,
+    pub batched: &'static str,
+    pub group_open: &'static str,
+    pub group_closed: &'static str,
+    pub crash_loop: &'static str,
+    pub paused: &'static str,
+    pub health: &'static str,
+    pub rx: &'static str,
+    pub tx: &'static str,
+    pub spinner: &'static [&'static str],
+    pub load: &'static [&'static str], // Low to high, one character per level
+}
+
+impl Symbols {
+    pub const UNICODE: Symbols = Symbols {
+        running: "●",
+        stopped: "○",
+        warning: "⚠",
+        privileged: "⚡",
+        marked: "◆",
+        batched: "✓",
+        group_open: "▾",
+        group_closed: "▸",
+        crash_loop: "↻",
+        paused: "⏸",
+        health: "♥",
+        rx: "⬇",
+        tx: "⬆",
+        spinner: IconSet::SPINNER,
+        load: &["▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"],
+    };
+
+    pub const ASCII: Symbols = Symbols {
+        running: "*",
+        stopped: "-",
+        warning: "!",
+        privileged: "#",
+        marked: "+",
+        batched: "x",
+        group_open: "-",
+        group_closed: "+",
+        crash_loop: "@",
+        paused: "=",
+        health: "~",
+        rx: "v",
+        tx: "^",
+        spinner: &["|", "/", "-", "\\"],
+        load: &[".", ":", "-", "=", "+", "*", "#", "@"],
+    };
+
+    pub const NERD: Symbols = Symbols {
+        running: IconSet::CONTAINER,
+        stopped: IconSet::CONTAINER,
+        warning: "\u{f071}",
+        privileged: "\u{f0e7}",
+        marked: "\u{f00c}",
+        batched: "\u{f14a}",
+        group_open: "\u{f078}",
+        group_closed: "\u{f054}",
+        crash_loop: "\u{f021}",
+        paused: "\u{f04c}",
+        health: "\u{f21e}",
+        rx: "\u{f063}",
+        tx: "\u{f062}",
+        spinner: IconSet::SPINNER,
+        load: &["▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"],
+    };
+
+    // "unicode", "ascii", "nerd", or "auto" (ASCII on the Linux console or non-UTF-8 locales)
+    pub fn from_config(name: &str) -> &'static Symbols {
+        match name {
+            "ascii" => &Self::ASCII,
+            "nerd" => &Self::NERD,
+            "unicode" => &Self::UNICODE,
+            _ => Self::detect(),
+        }
+    }
+
+    fn detect() -> &'static Symbols {
+        let term = std::env::var("TERM").unwrap_or_default();
+        let locale = ["LC_ALL", "LC_CTYPE", "LANG"]
+            .iter()
+            .filter_map(|v| std::env::var(v).ok())
+            .find(|v| !v.is_empty())
+            .unwrap_or_default()
+            .to_uppercase();
+        if term == "linux" || term == "dumb" || !(locale.contains("UTF-8") || locale.contains("UTF8")) {
+            &Self::ASCII
+        } else {
+            &Self::UNICODE
+        }
+    }
+}
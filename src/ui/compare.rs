@@ -0,0 +1,114 @@
+use ratatui::{
+    layout::{Constraint, Direction, Layout, Rect},
+    style::{Modifier, Style},
+    text::{Line, Span},
+    widgets::{Block, Borders, BorderType, Clear, Paragraph, Sparkline},
+    Frame,
+};
+use crate::app::{App, CompareColumn, CompareView};
+use crate::config::Theme;
+use super::util::ByteUnits;
+
+pub fn draw(f: &mut Frame, app: &App, view: &CompareView, theme: &Theme) {
+    let area = super::centered_rect(90, 80, f.size());
+    f.render_widget(Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(" COMPARE (Esc: Close) ", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))
+        .border_style(Style::default().fg(theme.selection_bg))
+        .style(Style::default().bg(theme.background));
+
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let columns = Layout::default()
+        .direction(Direction::Horizontal)
+        .constraints([Constraint::Percentage(50), Constraint::Percentage(50)])
+        .split(inner);
+
+    for (i, col) in view.columns.iter().enumerate() {
+        let name = app.containers
+            .iter()
+            .find(|c| c.id == view.ids[i])
+            .map(|c| app.container_label(c))
+            .unwrap_or_else(|| view.ids[i].chars().take(12).collect());
+        draw_column(f, &name, col, app.units, columns[i], theme);
+    }
+}
+
+fn draw_column(f: &mut Frame, name: &str, col: &CompareColumn, units: ByteUnits, area: Rect, theme: &Theme) {
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Rounded)
+        .title(Span::styled(format!(" {} ", name), Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)));
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let stats = match &col.current {
+        Some(s) => s,
+        None => {
+            f.render_widget(Paragraph::new("Loading stats...").style(Style::default().fg(theme.border)), inner);
+            return;
+        }
+    };
+
+    let chunks = Layout::default()
+        .direction(Direction::Vertical)
+        .constraints([
+            Constraint::Length(1), // CPU label
+            Constraint::Length(3), // CPU sparkline
+            Constraint::Length(1), // Memory label
+            Constraint::Length(3), // Memory sparkline
+            Constraint::Min(2),    // Net / Block totals
+        ])
+        .split(inner);
+
+    let cpu = match col.paused {
+        true => "CPU paused".to_string(),
+        false => format!("CPU {}%", col.cpu_history.last().copied().unwrap_or(0)),
+    };
+    f.render_widget(Paragraph::new(cpu).style(Style::default().fg(theme.chart_mid).add_modifier(Modifier::BOLD)), chunks[0]);
+    f.render_widget(
+        Sparkline::default()
+            .data(&col.cpu_history)
+            .max(100)
+            .style(Style::default().fg(theme.chart_low))
+            .bar_set(ratatui::symbols::bar::NINE_LEVELS),
+        chunks[1],
+    );
+
+    let usage = stats.memory_usage().unwrap_or(0);
+    let limit = stats.memory_limit().map(|l| units.format(l)).unwrap_or_else(|| "N/A".to_string());
+    f.render_widget(
+        Paragraph::new(format!("MEM {} / {}", units.format(usage), limit))
+            .style(Style::default().fg(theme.memory_chart).add_modifier(Modifier::BOLD)),
+        chunks[2],
+    );
+    f.render_widget(
+        Sparkline::default()
+            .data(&col.mem_history)
+            .max(100)
+            .style(Style::default().fg(theme.memory_chart))
+            .bar_set(ratatui::symbols::bar::NINE_LEVELS),
+        chunks[3],
+    );
+
+    let (rx, tx) = stats.network_totals();
+    let block = match stats.block_totals() {
+        Some((read, write)) => format!("read {}  write {}", units.format(read), units.format(write)),
+        None => "N/A".to_string(),
+    };
+    let text = vec![
+        Line::from(vec![
+            Span::styled("NET  ", Style::default().fg(theme.network_rx)),
+            Span::raw(format!("rx {}  tx {}", units.format(rx), units.format(tx))),
+        ]),
+        Line::from(vec![
+            Span::styled("BLK  ", Style::default().fg(theme.chart_high)),
+            Span::raw(block),
+        ]),
+    ];
+    f.render_widget(Paragraph::new(text).style(Style::default().fg(theme.foreground)), chunks[4]);
+}
@@ -1,24 +1,75 @@
 use ratatui::{
-    layout::{Constraint, Rect},
+    layout::{Alignment, Constraint, Direction, Layout, Rect},
     style::{Modifier, Style},
-    text::Span,
-    widgets::{Block, Borders, BorderType, Cell, Row, Table, TableState},
+    text::{Line, Span},
+    widgets::{Block, Borders, BorderType, Cell, Paragraph, Row, Table, TableState},
     Frame,
 };
 use crate::app::App;
 use crate::config::Theme;
-use crate::theme::icons::IconSet;
 
 pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    let highlight_matches = app.is_highlight_filter() && !app.filter_query.is_empty();
+    let title = if highlight_matches {
+        let count = app.containers.iter().filter(|c| app.matches_filter(c)).count();
+        format!(" CONTAINERS ({} matches, n/N to jump) ", count)
+    } else if !app.filter_query.is_empty() {
+        format!(" CONTAINERS ({} of {}) ", app.containers.len(), app.all_containers.len())
+    } else if app.follow_newest {
+        " CONTAINERS | following newest (move to stop) ".to_string()
+    } else {
+        " CONTAINERS ".to_string()
+    };
+    let title = if app.group_by_project {
+        format!("{}| by project ({} fold, {} unfold) ", title, app.config.keys.collapse_group, app.config.keys.expand_groups)
+    } else {
+        title
+    };
+    let title = if app.batch.is_empty() {
+        title
+    } else {
+        format!("{}| {} in batch (Esc clears) ", title, app.batch.len())
+    };
+    let title = if app.refresh_paused {
+        format!("{}| {} PAUSED ({} to resume) ", title, app.symbols.paused, app.config.keys.toggle_pause)
+    } else {
+        title
+    };
+
     let block = Block::default()
         .borders(Borders::ALL)
         .border_type(BorderType::Rounded)
-        .title(" CONTAINERS ");
+        .title(title);
     
     let inner = block.inner(area);
     f.render_widget(block, area);
 
-    let header_cells = ["State", "ID", "Name", "Image", "IP", "Status", "Ports"]
+    // A list from before the daemon went away would be misleading, so say what's going on instead
+    if let Some(reason) = &app.daemon_down {
+        let chunks = Layout::default()
+            .direction(Direction::Vertical)
+            .constraints([Constraint::Percentage(40), Constraint::Length(3), Constraint::Min(0)])
+            .split(inner);
+        let spinner = app.symbols.spinner[app.spinner_frame % app.symbols.spinner.len()];
+        let text = vec![
+            Line::from(Span::styled(format!("{} Cannot reach Docker daemon — retrying…", spinner), Style::default().fg(theme.stopped).add_modifier(Modifier::BOLD))),
+            Line::from(""),
+            Line::from(Span::styled(reason.clone(), Style::default().fg(theme.border))),
+        ];
+        f.render_widget(Paragraph::new(text).alignment(Alignment::Center).wrap(ratatui::widgets::Wrap { trim: true }), chunks[1]);
+        return;
+    }
+
+    // With show_ids the ID takes the main (badged) column and the names move to the narrow one
+    let mut headers = if app.show_ids {
+        vec!["State", "Name", "ID", "Image", "IP", "Status", "Ports"]
+    } else {
+        vec!["State", "ID", "Name", "Image", "IP", "Status", "Ports"]
+    };
+    if app.list_sizes {
+        headers.push("Disk");
+    }
+    let header_cells = headers
         .iter()
         .map(|h| Cell::from(*h).style(Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)));
     let header = Row::new(header_cells)
@@ -27,25 +78,71 @@ pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
         .bottom_margin(1);
 
     let rows = app.containers.iter().map(|c| {
-        let state_icon = if c.state == "running" {
-            Span::styled(IconSet::CONTAINER, Style::default().fg(theme.running))
+        let mut state_icon = vec![if c.state == "running" {
+            Span::styled(app.symbols.running, Style::default().fg(theme.running))
         } else {
-            Span::styled(IconSet::CONTAINER, Style::default().fg(theme.stopped))
-        };
+            Span::styled(app.symbols.stopped, Style::default().fg(theme.stopped))
+        }];
+        if let Some(health) = c.health() {
+            state_icon.push(Span::styled(app.symbols.health, Style::default().fg(health_color(health, theme))));
+        }
 
-        let cells = vec![
-            Cell::from(state_icon),
-            Cell::from(c.id.chars().take(12).collect::<String>()),
-            Cell::from(c.names.join(", ")),
+        let mut cells = vec![
+            Cell::from(Line::from(state_icon)),
+            secondary_cell(app, c),
+            name_cell(app, c, theme),
             Cell::from(c.image.clone()),
             Cell::from("127.0.0.1"), // Mock IP for now, actual IP needs inspection
             Cell::from(c.status.clone()),
-            Cell::from(c.ports.as_ref().unwrap_or(&vec![]).iter().map(|p| format!("{}:{}", p.public_port.unwrap_or(0), p.private_port)).collect::<Vec<_>>().join(", ")),
+            ports_cell(app, c, theme),
         ];
-        Row::new(cells).height(1).style(Style::default().fg(theme.foreground))
+        if app.list_sizes {
+            cells.push(disk_cell(app, c));
+        }
+        let row_style = match app.recent_change_level(&c.id) {
+            2 => Style::default().fg(theme.background).bg(theme.restarting).add_modifier(Modifier::BOLD),
+            1 => Style::default().fg(theme.restarting).add_modifier(Modifier::BOLD),
+            _ if highlight_matches && app.matches_filter(c) => match_style(theme),
+            _ => Style::default().fg(theme.foreground),
+        };
+        Row::new(cells).height(1).style(row_style)
     });
 
-    let t = Table::new(rows, [
+    // Grouped: a header above each project's rows (or alone when it's collapsed). The highlight
+    // moves down by the headers above the cursor, or sits on the selected header.
+    let mut selected_row = app.selected_index;
+    let rows: Vec<Row> = if app.groups.is_empty() {
+        rows.collect()
+    } else {
+        let mut members = rows;
+        let mut grouped = Vec::new();
+        let header_style = Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD);
+        for group in &app.groups {
+            let (symbol, count) = match group.first {
+                Some(_) => (app.symbols.group_open, format!("({})", group.total)),
+                None => (app.symbols.group_closed, format!("({} hidden)", group.total)),
+            };
+            if app.selected_group.as_ref() == Some(&group.name) {
+                selected_row = grouped.len();
+            }
+            grouped.push(Row::new(vec![
+                Cell::from(symbol),
+                Cell::from(""),
+                Cell::from(format!("{} {}", group.name, count)),
+            ]).height(1).style(header_style));
+            if let Some(first) = group.first {
+                for index in first..first + group.total {
+                    if index == app.selected_index && app.selected_group.is_none() {
+                        selected_row = grouped.len();
+                    }
+                    grouped.extend(members.next());
+                }
+            }
+        }
+        grouped
+    };
+
+    let mut widths = vec![
         Constraint::Length(3),
         Constraint::Length(12),
         Constraint::Percentage(20),
@@ -53,9 +150,13 @@ pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
         Constraint::Length(15),
         Constraint::Percentage(20),
         Constraint::Percentage(15),
-    ])
+    ];
+    if app.list_sizes {
+        widths.push(Constraint::Length(10));
+    }
+    let t = Table::new(rows, widths)
     .header(header)
-    .highlight_style(Style::default().fg(theme.selection_fg).bg(theme.selection_bg).add_modifier(Modifier::BOLD));
+    .highlight_style(selection_style(theme));
     
     // We need a mutable reference to TableState, but app.state is immutable here.
     // In a real refactor we'd pass &mut TableState. For now, we clone the state or use what we have.
@@ -63,7 +164,83 @@ pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
     // Let's assume we can't modify state here easily without changing signature.
     // We will construct a temporary state from app.selected_index
     let mut state = TableState::default();
-    state.select(Some(app.selected_index));
+    state.select(Some(selected_row));
     
     f.render_stateful_widget(t, inner, &mut state);
 }
+
+// Underlined when the filter hit was on a port rather than the name, image or ID
+fn ports_cell<'a>(app: &App, c: &crate::docker::Container, theme: &Theme) -> Cell<'a> {
+    let text = c.ports.as_ref().unwrap_or(&vec![]).iter().map(|p| format!("{}:{}", p.public_port.unwrap_or(0), p.private_port)).collect::<Vec<_>>().join(", ");
+    if app.port_matches(c) {
+        Cell::from(text).style(match_style(theme).add_modifier(Modifier::UNDERLINED))
+    } else {
+        Cell::from(text)
+    }
+}
+
+// Writable layer only: that's what grows when a container fills the disk with logs or temp files
+fn disk_cell<'a>(app: &App, c: &crate::docker::Container) -> Cell<'a> {
+    match c.size_rw {
+        Some(size) => Cell::from(app.units.format(size.max(0) as u64)),
+        None => Cell::from("-"),
+    }
+}
+
+// Green when passing, amber while the first checks run, red once they fail
+pub fn health_color(status: &str, theme: &Theme) -> ratatui::style::Color {
+    match status {
+        "healthy" => theme.running,
+        "unhealthy" => theme.stopped,
+        _ => theme.restarting,
+    }
+}
+
+pub fn selection_style(theme: &Theme) -> Style {
+    Style::default().fg(theme.selection_fg).bg(theme.selection_bg).add_modifier(Modifier::BOLD)
+}
+
+// Filter matches reuse the selection palette without the background so the cursor still stands out
+fn match_style(theme: &Theme) -> Style {
+    Style::default().fg(theme.selection_bg).add_modifier(Modifier::BOLD)
+}
+
+// Containers bind-mounting sensitive host paths get a warning badge in front of the name
+// and privileged / over-capable ones get their own badge
+fn name_cell<'a>(app: &App, c: &'a crate::docker::Container, theme: &Theme) -> Cell<'a> {
+    let mut spans = Vec::new();
+    if app.batch.contains(&c.id) {
+        spans.push(Span::styled(format!("{} ", app.symbols.batched), Style::default().fg(theme.running).add_modifier(Modifier::BOLD)));
+    }
+    if app.marked.contains(&c.id) {
+        spans.push(Span::styled(format!("{} ", app.symbols.marked), Style::default().fg(theme.selection_bg).add_modifier(Modifier::BOLD)));
+    }
+    if !c.sensitive_mounts().is_empty() {
+        spans.push(Span::styled(format!("{} ", app.symbols.warning), Style::default().fg(theme.stopped).add_modifier(Modifier::BOLD)));
+    }
+    // Pulses with the spinner so a crash loop catches the eye
+    if app.crash_loop(&c.id).is_some() {
+        let mut style = Style::default().fg(theme.stopped).add_modifier(Modifier::BOLD);
+        if app.spinner_frame % 2 == 0 {
+            style = style.add_modifier(Modifier::REVERSED);
+        }
+        spans.push(Span::styled(format!("{} ", app.symbols.crash_loop), style));
+    }
+    if app.privileged.contains_key(&c.id) {
+        spans.push(Span::styled(format!("{} ", app.symbols.privileged), Style::default().fg(theme.restarting).add_modifier(Modifier::BOLD)));
+    }
+    spans.push(Span::raw(app.container_label(c)));
+    if app.is_self(&c.id) {
+        spans.push(Span::styled(" (this)", Style::default().fg(theme.border)));
+    }
+    Cell::from(Line::from(spans))
+}
+
+// Whichever of short ID / names isn't the container's label
+fn secondary_cell<'a>(app: &App, c: &crate::docker::Container) -> Cell<'a> {
+    if app.show_ids {
+        Cell::from(c.names.iter().map(|n| n.trim_start_matches('/')).collect::<Vec<_>>().join(", "))
+    } else {
+        Cell::from(c.id.chars().take(12).collect::<String>())
+    }
+}
@@ -0,0 +1,291 @@
+use ratatui::{
+    layout::Rect,
+    style::{Modifier, Style},
+    text::{Line, Span},
+    widgets::{Block, Borders, BorderType, Paragraph, Wrap},
+    Frame,
+};
+use crate::app::App;
+use crate::config::Theme;
+use crate::docker::sensitive_mounts;
+
+pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Rounded)
+        .title(Span::styled(" DETAILS ", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)));
+
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let inspect = match &app.current_inspection {
+        Some(i) => i,
+        None => {
+            let msg = if app.is_loading_details { "Loading..." } else { "No container selected" };
+            f.render_widget(Paragraph::new(msg).style(Style::default().fg(theme.border)), inner);
+            return;
+        }
+    };
+
+    let label = |l: &str| Span::styled(format!("{:<9}", l), Style::default().fg(theme.header_fg));
+    let mut lines = vec![
+        Line::from(vec![label("Name"), Span::raw(inspect.name.as_deref().unwrap_or("").trim_start_matches('/').to_string())]),
+        Line::from(vec![label("ID"), Span::raw(app.id_text(&inspect.id))]),
+        Line::from(vec![label("Image"), Span::raw(inspect.config.as_ref().map(|c| c.image.clone()).unwrap_or_default())]),
+        Line::from(vec![label("Created"), Span::raw(inspect.created.clone().unwrap_or_default())]),
+    ];
+    if let Some((running, age)) = inspect.state.as_ref().and_then(|s| s.since()) {
+        let text = if running {
+            format!("up {}", super::util::format_age(age))
+        } else {
+            format!("exited {} ago", super::util::format_age(age))
+        };
+        lines.push(Line::from(vec![label("Uptime"), Span::raw(text)]));
+    }
+    if let Some(health) = inspect.state.as_ref().and_then(|s| s.health.as_ref()) {
+        let status = health.status.as_deref().unwrap_or("unknown");
+        let mut spans = vec![
+            label("Health"),
+            Span::styled(format!("{} {}", app.symbols.health, status), Style::default().fg(super::containers::health_color(status, theme))),
+        ];
+        if let Some(streak) = health.failing_streak.filter(|n| *n > 0) {
+            spans.push(Span::styled(format!(" ({} failing in a row)", streak), Style::default().fg(theme.border)));
+        }
+        lines.push(Line::from(spans));
+    }
+    // First thing worth knowing about a dead container
+    if let Some((reason, clean)) = inspect.exit_reason() {
+        let style = if clean {
+            Style::default().fg(theme.foreground)
+        } else {
+            Style::default().fg(theme.stopped).add_modifier(Modifier::BOLD)
+        };
+        lines.insert(0, Line::from(vec![label("Exit"), Span::styled(reason, style)]));
+    }
+    // Stopped containers have no stats by nature; a running one without them means the daemon failed us
+    let state = app.get_selected_container().map(|c| c.state.as_str()).unwrap_or("");
+    if app.is_paused(&inspect.id) {
+        lines.push(Line::from(vec![label("CPU"), Span::styled("paused", Style::default().fg(theme.restarting))]));
+    } else if App::is_stopped(state) {
+        lines.push(Line::from(vec![label("CPU"), Span::styled("—", Style::default().fg(theme.border))]));
+    } else if app.current_stats.is_none() && !app.is_loading_details {
+        lines.push(Line::from(vec![label("CPU"), Span::styled("stats? (the daemon returned no stats)", Style::default().fg(theme.restarting))]));
+    } else if let Some(stats) = &app.current_stats {
+        let cpu = super::calculate_cpu_usage(stats, &app.previous_stats);
+        let color = super::util::heat_color(app.cpu_heat(cpu), theme);
+        lines.push(Line::from(vec![label("CPU"), Span::styled(format!("{:.1}%", cpu), Style::default().fg(color))]));
+        if !app.container_cpu_history.is_empty() && area.width > 2 + 9 + 10 {
+            // Borders, the 9-wide label and room for the range (" 100–100%") after the chart,
+            // which says what its bottom and top mean
+            let width = (area.width as usize).saturating_sub(2 + 9 + 10);
+            let shown = &app.container_cpu_history[app.container_cpu_history.len().saturating_sub(width)..];
+            let (min, max) = shown.iter().fold((f64::MAX, f64::MIN), |(lo, hi), v| (lo.min(*v), hi.max(*v)));
+            let range = format!(" {:.0}–{:.0}%", min, max);
+            lines.push(Line::from(vec![
+                label("History"),
+                Span::styled(super::util::sparkline_text(&app.container_cpu_history, width), Style::default().fg(theme.chart_low)),
+                Span::styled(range, Style::default().fg(theme.border)),
+            ]));
+        }
+    }
+    if let Some(usage) = app.current_stats.as_ref().and_then(|s| s.memory_usage()) {
+        let limit = app.current_stats.as_ref().and_then(|s| s.memory_limit()).map(|l| app.units.format(l)).unwrap_or_else(|| "N/A".to_string());
+        let mut spans = vec![label("Memory"), Span::raw(format!("{} / {}", app.units.format(usage), limit))];
+        if let Some(percent) = app.current_stats.as_ref().and_then(|s| s.memory_percent()) {
+            let color = super::util::heat_color(crate::app::heat(percent), theme);
+            spans.push(Span::styled(format!(" ({:.0}%)", percent), Style::default().fg(color)));
+        }
+        lines.push(Line::from(spans));
+    }
+    if let Some(stats) = &app.current_stats {
+        let text = match stats.block_totals() {
+            Some((read, write)) => format!("R {} / W {}", app.units.format(read), app.units.format(write)),
+            None => "N/A".to_string(),
+        };
+        lines.push(Line::from(vec![label("Block IO"), Span::raw(text)]));
+    }
+    // Needs two samples; host-networked containers have no interfaces of their own
+    let rates = match (&app.current_stats, &app.previous_stats) {
+        (Some(current), Some(previous)) if current.networks.is_some() => current.network_rates(previous),
+        _ => None,
+    };
+    if let Some((rx, tx)) = rates {
+        lines.push(Line::from(vec![
+            label("Net"),
+            Span::styled(format!("{}{}/s ", app.symbols.rx, app.units.format(rx as u64)), Style::default().fg(theme.network_rx)),
+            Span::styled(format!("{}{}/s", app.symbols.tx, app.units.format(tx as u64)), Style::default().fg(theme.network_tx)),
+        ]));
+    }
+    if let Some(cmd) = inspect.config.as_ref().and_then(|c| c.cmd.as_ref()) {
+        lines.push(Line::from(vec![label("Cmd"), Span::raw(cmd.join(" "))]));
+    }
+    if let Some(policy) = inspect.host_config.as_ref().and_then(|h| h.restart_policy.as_ref()) {
+        lines.push(Line::from(vec![label("Restart"), Span::raw(policy.name.clone())]));
+    }
+    // The daemon clears OOMKilled when the container starts again, so it marks the latest exit
+    let restarts = inspect.restart_count.unwrap_or(0);
+    let oom = inspect.state.as_ref().and_then(|s| s.oom_killed).unwrap_or(false);
+    let oom_note = if oom { " (OOMKilled)" } else { "" };
+    let alarming = Style::default().fg(theme.stopped).add_modifier(Modifier::BOLD);
+    match app.crash_loop(&inspect.id) {
+        Some(recent) => lines.push(Line::from(vec![
+            label("Restarts"),
+            Span::styled(format!("{} {} ({} in last minute, crash loop){}", restarts, app.symbols.crash_loop, recent, oom_note), alarming),
+        ])),
+        None if restarts > 0 || oom => {
+            let style = if oom || restarts >= crate::app::HIGH_RESTART_COUNT { alarming } else { Style::default() };
+            lines.push(Line::from(vec![label("Restarts"), Span::styled(format!("{}{}", restarts, oom_note), style)]));
+        }
+        None => {}
+    }
+
+    if let Some(last) = app.last_actions.get(&inspect.id) {
+        let (outcome, color) = if last.ok { ("ok", theme.running) } else { ("failed", theme.stopped) };
+        lines.push(Line::from(vec![
+            label("Action"),
+            Span::raw(format!("{} {} ago ", last.verb, super::util::format_age(last.at.elapsed()))),
+            Span::styled(format!("({})", outcome), Style::default().fg(color)),
+        ]));
+    }
+
+    // Ports, one per line, cut to what fits under the lines above
+    let ports = inspect.port_mappings();
+    if !ports.is_empty() {
+        let room = (area.height as usize).saturating_sub(2 + lines.len()).max(2);
+        let shown = if ports.len() > room { room - 1 } else { ports.len() };
+        for (i, port) in ports.iter().take(shown).enumerate() {
+            let name = if i == 0 { "Ports" } else { "" };
+            lines.push(Line::from(vec![label(name), Span::raw(port.clone())]));
+        }
+        if ports.len() > shown {
+            lines.push(Line::from(vec![label(""), Span::styled(format!("+{} more", ports.len() - shown), Style::default().fg(theme.border))]));
+        }
+    }
+
+    let privilege_warnings = inspect.privilege_warnings();
+    if !privilege_warnings.is_empty() {
+        lines.push(Line::from(""));
+        for w in privilege_warnings {
+            lines.push(Line::from(Span::styled(
+                format!("{} {}", app.symbols.privileged, w),
+                Style::default().fg(theme.restarting).add_modifier(Modifier::BOLD),
+            )));
+        }
+    }
+
+    // Mounts, with sensitive host paths flagged and explained
+    let mounts = inspect.mounts.as_deref().unwrap_or(&[]);
+    if !mounts.is_empty() {
+        lines.push(Line::from(""));
+        lines.push(Line::from(Span::styled("Mounts", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD))));
+        let flagged = sensitive_mounts(mounts);
+        let width = (area.width as usize).saturating_sub(2);
+        for m in mounts {
+            let mode = if m.rw.unwrap_or(true) { "rw" } else { "ro" };
+            // Named volumes show their name and disk usage instead of the daemon's internal path
+            let (kind, source, extra) = match (&m.type_.as_deref(), &m.name) {
+                (Some("volume"), Some(name)) => {
+                    let size = app.volume_sizes
+                        .get(name)
+                        .map(|b| app.units.format(*b))
+                        .unwrap_or_else(|| "unknown".to_string());
+                    ("volume", name.as_str(), format!(", {}", size))
+                }
+                (kind, _) => (kind.unwrap_or("bind"), m.source.as_deref().unwrap_or("?"), String::new()),
+            };
+            let head = format!(" {:<6} ", kind);
+            let tail = format!(" -> {} ({}{})", m.destination.as_deref().unwrap_or("?"), mode, extra);
+            // Long host paths lose their middle so the row fits; one column goes to the badge
+            let room = width.saturating_sub(1 + head.chars().count() + tail.chars().count()).max(12);
+            let text = format!("{}{}{}", head, super::util::truncate_middle(source, room), tail);
+            match flagged.iter().find(|(f, _)| std::ptr::eq(*f, m)) {
+                Some((_, reason)) => {
+                    lines.push(Line::from(Span::styled(
+                        format!("{}{}", app.symbols.warning, text),
+                        Style::default().fg(theme.stopped).add_modifier(Modifier::BOLD),
+                    )));
+                    lines.push(Line::from(Span::styled(format!("   {}", reason), Style::default().fg(theme.stopped))));
+                }
+                None => lines.push(Line::from(Span::raw(format!(" {}", text)))),
+            }
+        }
+    }
+
+    // Environment, masked and narrowed by the env filter
+    let total = inspect.config.as_ref().and_then(|c| c.env.as_ref()).map(|e| e.len()).unwrap_or(0);
+    if total > 0 {
+        let env = app.filtered_env();
+        let title = if app.is_typing_env_filter {
+            format!("Env  filter: {}_ ({}/{})", app.env_filter, env.len(), total)
+        } else if !app.env_filter.is_empty() {
+            format!("Env  filter: {} ({}/{}, {} to change)", app.env_filter, env.len(), total, app.config.keys.filter_env)
+        } else {
+            format!("Env ({} to filter)", app.config.keys.filter_env)
+        };
+        lines.push(Line::from(""));
+        lines.push(Line::from(Span::styled(title, Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD))));
+        if env.is_empty() {
+            lines.push(Line::from(Span::styled(" No matching variables", Style::default().fg(theme.border))));
+        }
+        for (key, value) in env {
+            lines.push(Line::from(vec![
+                Span::styled(format!(" {}", key), Style::default().fg(theme.selection_bg)),
+                Span::raw(format!("={}", value)),
+            ]));
+        }
+    }
+
+    let p = Paragraph::new(lines)
+        .wrap(Wrap { trim: false })
+        .style(Style::default().fg(theme.foreground));
+    f.render_widget(p, inner);
+}
+
+// Only the environment, one KEY=VALUE per row cut to the panel width, scrolled on its own
+pub fn draw_env(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    let env = app.filtered_env();
+    let total = app.current_inspection.as_ref().and_then(|i| i.config.as_ref()).and_then(|c| c.env.as_ref()).map(|e| e.len()).unwrap_or(0);
+    let masking = if app.reveal_env { "revealed" } else { "masked" };
+    let mut title = format!(" ENV ({}/{}, {}, {} to toggle) ", env.len(), total, masking, app.config.keys.reveal_env);
+    if app.is_typing_env_filter {
+        title.push_str(&format!("| filter: {}_ ", app.env_filter));
+    } else if !app.env_filter.is_empty() {
+        title.push_str(&format!("| filter: {} ", app.env_filter));
+    }
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Rounded)
+        .title(Span::styled(title, Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)));
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    if app.current_inspection.is_none() {
+        let msg = if app.is_loading_details { "Loading..." } else { "No container selected" };
+        f.render_widget(Paragraph::new(msg).style(Style::default().fg(theme.border)), inner);
+        return;
+    }
+    if env.is_empty() {
+        let msg = if total == 0 { "No environment variables" } else { "No matching variables" };
+        f.render_widget(Paragraph::new(msg).style(Style::default().fg(theme.border)), inner);
+        return;
+    }
+
+    let width = inner.width as usize;
+    let height = inner.height as usize;
+    let skip = app.env_scroll.min(env.len().saturating_sub(height));
+    let lines: Vec<Line> = env
+        .iter()
+        .skip(skip)
+        .take(height)
+        .map(|(key, value)| {
+            let text = super::util::truncate_ellipsis(&format!("{}={}", key, value), width);
+            // A key cut short by the width is all key colour
+            let cut = if text.starts_with(key.as_str()) { key.len() } else { text.len() };
+            Line::from(vec![
+                Span::styled(text[..cut].to_string(), Style::default().fg(theme.selection_bg)),
+                Span::raw(text[cut..].to_string()),
+            ])
+        })
+        .collect();
+    f.render_widget(Paragraph::new(lines).style(Style::default().fg(theme.foreground)), inner);
+}
@@ -0,0 +1,78 @@
+use ratatui::{
+    layout::{Constraint, Direction, Layout},
+    style::{Modifier, Style},
+    text::{Line, Span},
+    widgets::{Block, Borders, BorderType, Clear, Paragraph},
+    Frame,
+};
+use crate::app::{App, EVENT_TYPES};
+use crate::config::Theme;
+
+pub fn draw(f: &mut Frame, app: &App, theme: &Theme) {
+    let view = match &app.events_view {
+        Some(v) => v,
+        None => return,
+    };
+
+    let area = super::centered_rect(80, 80, f.size());
+    f.render_widget(Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(
+            format!(" DAEMON EVENTS [{}] ", EVENT_TYPES[view.filter]),
+            Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD),
+        ))
+        .border_style(Style::default().fg(theme.selection_bg))
+        .style(Style::default().bg(theme.background));
+
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let chunks = Layout::default()
+        .direction(Direction::Vertical)
+        .constraints([Constraint::Min(1), Constraint::Length(1)])
+        .split(inner);
+
+    let events = app.filtered_events();
+    let height = chunks[0].height as usize;
+    // Keep the selected (or newest) event on the bottom row
+    let last = view.scroll.unwrap_or(events.len().saturating_sub(1));
+    let start = (last + 1).saturating_sub(height);
+
+    let lines: Vec<Line> = if events.is_empty() {
+        vec![Line::from(Span::styled("Waiting for events...", Style::default().fg(theme.border)))]
+    } else {
+        events
+            .iter()
+            .skip(start)
+            .take(height)
+            .map(|e| {
+                let time = e.time
+                    .and_then(|t| chrono::DateTime::from_timestamp(t, 0))
+                    .map(|t| t.with_timezone(&chrono::Local).format("%H:%M:%S").to_string())
+                    .unwrap_or_else(|| "--:--:--".to_string());
+                let action_color = match e.action.as_str() {
+                    "start" | "create" | "pull" | "connect" => theme.running,
+                    "die" | "kill" | "stop" | "destroy" | "delete" | "oom" => theme.stopped,
+                    _ => theme.restarting,
+                };
+                Line::from(vec![
+                    Span::styled(format!("{} ", time), Style::default().fg(theme.border)),
+                    Span::styled(format!("{:<10}", e.type_), Style::default().fg(theme.header_fg)),
+                    Span::styled(format!("{:<14}", e.action), Style::default().fg(action_color).add_modifier(Modifier::BOLD)),
+                    Span::styled(e.subject(), Style::default().fg(theme.foreground)),
+                ])
+            })
+            .collect()
+    };
+    f.render_widget(Paragraph::new(lines), chunks[0]);
+
+    let follow = if view.scroll.is_none() { "FOLLOWING" } else { "PAUSED (End: follow)" };
+    let footer = Line::from(vec![
+        Span::styled(follow, Style::default().fg(theme.running).add_modifier(Modifier::BOLD)),
+        Span::styled(" | Tab: Type Filter | ↑/↓ PgUp/PgDn: Scroll | Esc: Close", Style::default().fg(theme.border)),
+    ]);
+    f.render_widget(Paragraph::new(footer), chunks[1]);
+}
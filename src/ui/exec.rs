@@ -0,0 +1,78 @@
+use ratatui::{
+    layout::{Constraint, Direction, Layout, Rect},
+    style::{Modifier, Style},
+    text::{Line, Span},
+    widgets::{Block, Borders, BorderType, Clear, Paragraph},
+    Frame,
+};
+use crate::app::ExecView;
+use crate::config::Theme;
+
+pub fn draw_prompt(f: &mut Frame, title: &str, input: &str, theme: &Theme) {
+    let size = f.size();
+    let width = (size.width * 60 / 100).max(20).min(size.width);
+    let area = Rect::new((size.width - width) / 2, size.height.saturating_sub(3) / 2, width, 3.min(size.height));
+
+    f.render_widget(Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Rounded)
+        .title(title.to_string())
+        .border_style(Style::default().fg(theme.running).add_modifier(Modifier::BOLD))
+        .style(Style::default().bg(theme.background));
+
+    let p = Paragraph::new(input.to_string())
+        .block(block)
+        .style(Style::default().fg(theme.foreground));
+    f.render_widget(p, area);
+}
+
+pub fn draw_output(f: &mut Frame, view: &ExecView, theme: &Theme) {
+    let area = super::centered_rect(80, 80, f.size());
+    f.render_widget(Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(format!(" EXEC: {} ", view.command), Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))
+        .border_style(Style::default().fg(theme.selection_bg))
+        .style(Style::default().bg(theme.background));
+
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let chunks = Layout::default()
+        .direction(Direction::Vertical)
+        .constraints([Constraint::Min(1), Constraint::Length(1)])
+        .split(inner);
+
+    let lines: Vec<Line> = if !view.finished {
+        vec![Line::from(Span::styled("Running...", Style::default().fg(theme.border)))]
+    } else if view.lines.is_empty() {
+        vec![Line::from(Span::styled("(no output)", Style::default().fg(theme.border)))]
+    } else {
+        view.lines
+            .iter()
+            .map(|(is_stderr, l)| {
+                let style = if *is_stderr { Style::default().fg(theme.stopped) } else { Style::default().fg(theme.foreground) };
+                Line::from(Span::styled(l.as_str(), style))
+            })
+            .collect()
+    };
+
+    let p = Paragraph::new(lines).scroll((view.scroll as u16, 0));
+    f.render_widget(p, chunks[0]);
+
+    let (status, color) = match view.exit_code {
+        Some(0) => ("exit code: 0".to_string(), theme.running),
+        Some(code) => (format!("exit code: {}", code), theme.stopped),
+        None if view.finished => ("exit code: unknown".to_string(), theme.restarting),
+        None => ("running".to_string(), theme.restarting),
+    };
+    let footer = Line::from(vec![
+        Span::styled(status, Style::default().fg(color).add_modifier(Modifier::BOLD)),
+        Span::styled(" | ↑/↓ PgUp/PgDn: Scroll | Esc: Close", Style::default().fg(theme.border)),
+    ]);
+    f.render_widget(Paragraph::new(footer), chunks[1]);
+}
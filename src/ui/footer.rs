@@ -5,7 +5,7 @@ use ratatui::{
     widgets::{Block, Borders, BorderType, Paragraph},
     Frame,
 };
-use crate::app::App;
+use crate::app::{App, LogMode};
 use crate::config::Theme;
 
 pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
@@ -18,13 +18,18 @@ pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
 
     let k = &app.config.keys;
 
-    // Helper to format key
-    let fmt = |k: &str, d: &str| format!("[{}] {}", k.to_uppercase(), d);
+    // Helper to format key. Single characters are case-sensitive bindings ("E" and "e" differ), so
+    // only named keys and combinations ("tab", "ctrl+e") are normalised to upper case.
+    let fmt = |k: &str, d: &str| {
+        let key = if k.chars().count() == 1 { k.to_string() } else { k.to_uppercase() };
+        format!("[{}] {}", key, d)
+    };
 
-    let management = format!("{} {} {} {} | ", 
+    let management = format!("{} {} {} {} {} | ", 
         fmt(&k.toggle_wizard, "Wizard"),
         fmt(&k.edit, "Edit"),
         fmt(&k.shell, "Shell"),
+        fmt(&k.exec_command, "Exec"),
         fmt(&k.db_cli, "DB CLI")
     );
 
@@ -45,6 +50,12 @@ pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
         fmt(&k.quit, "Quit")
     );
 
+    // The refresh interval only matters when the logs are re-fetched rather than streamed
+    let log_refresh = match app.active_log_mode {
+        LogMode::Stream => String::new(),
+        LogMode::Poll | LogMode::Tail => format!(" {}", fmt(&k.cycle_log_refresh, &format!("refresh {}", app.log_refresh_label()))),
+    };
+
     let text = vec![
         Line::from(vec![
             Span::styled("MANAGEMENT: ", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)),
@@ -55,9 +66,35 @@ pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
         Line::from(vec![
             Span::styled("GENERAL: ", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)),
             Span::raw(general),
+            Span::raw(" | "),
+            Span::raw(fmt(&k.toggle_log_mode, "Logs: ")),
+            Span::styled(app.active_log_mode.label(), Style::default().fg(theme.running).add_modifier(Modifier::BOLD)),
+            Span::raw(log_refresh),
+            Span::raw(format!(" {}", fmt("PgUp/PgDn Home/End", "Scroll"))),
+            Span::raw(format!(" | Stats every {}", app.stats_interval_label())),
         ]),
     ];
 
+    // Flash the alert on top of the shortcut lines while a threshold is exceeded or something crash-loops
+    let alert = match (app.crash_loop_alert(), app.resource_alert()) {
+        (Some(crash), Some(resource)) => Some(format!("CRASH LOOP: {} | RESOURCE ALERT: {}", crash, resource)),
+        (Some(crash), None) => Some(format!("CRASH LOOP: {}", crash)),
+        (None, Some(resource)) => Some(format!("RESOURCE ALERT: {}", resource)),
+        (None, None) => None,
+    };
+    let text = match alert {
+        Some(alert) => {
+            let mut style = Style::default().fg(theme.stopped).add_modifier(Modifier::BOLD);
+            if app.spinner_frame % 2 == 0 {
+                style = style.add_modifier(Modifier::REVERSED);
+            }
+            let mut lines = vec![Line::from(Span::styled(format!(" {} {} ", app.symbols.warning, alert), style))];
+            lines.extend(text.into_iter().take(1));
+            lines
+        }
+        None => text,
+    };
+
     let p = Paragraph::new(text).style(Style::default().fg(theme.foreground));
     f.render_widget(p, inner);
 }
@@ -0,0 +1,145 @@
+use ratatui::{
+    layout::{Constraint, Direction, Layout},
+    style::{Modifier, Style},
+    text::{Line, Span},
+    widgets::{Block, Borders, BorderType, Clear, Paragraph},
+    Frame,
+};
+use crate::app::App;
+use crate::config::{KeyConfig, Theme};
+
+// Every binding the help overlay lists, grouped by where it applies. Configurable keys are read
+// from the config, so rebinding one shows up here and in the footer alike; the few fixed keys
+// are spelled out.
+pub fn bindings(k: &KeyConfig) -> Vec<(&'static str, Vec<(String, &'static str)>)> {
+    let key = |s: &String| s.clone();
+    let fixed = |s: &str| s.to_string();
+    vec![
+        ("Global", vec![
+            (key(&k.toggle_help), "Show or close this help"),
+            (key(&k.quit), "Quit"),
+            (key(&k.refresh), "Refresh the container list"),
+            (key(&k.toggle_pause), "Pause or resume refreshing"),
+            (fixed("/"), "Filter containers (Esc clears)"),
+            (key(&k.toggle_wizard), "Wizard (also Tab / c)"),
+            (key(&k.toggle_events), "Daemon events"),
+            (key(&k.toggle_images), "Images"),
+            (key(&k.prune), "Prune stopped containers / dangling images"),
+            (key(&k.show_last_error), "Last error in full"),
+            (key(&k.toggle_zen), "Hide header and footer"),
+            (key(&k.toggle_ids), "Show IDs instead of names"),
+            (format!("{} {}", k.shrink_list, k.grow_list), "Narrow / widen the list"),
+            (format!("{} {}", k.shrink_logs, k.grow_logs), "Shrink / grow the logs row"),
+        ]),
+        ("Container list", vec![
+            (format!("{} {}", k.down, k.up), "Move the selection"),
+            (format!("{} {}", k.next_match, k.prev_match), "Next / previous filter match"),
+            (key(&k.next_problem), "Next container with a problem"),
+            (key(&k.cycle_sort), "Cycle the sort order"),
+            (key(&k.toggle_groups), "Group by Compose project (actions on a header hit all of it)"),
+            (format!("{} {}", k.collapse_group, k.expand_groups), "Fold this group / unfold all"),
+            (key(&k.mark), "Mark for compare"),
+            (key(&k.compare), "Compare marked containers"),
+            (key(&k.batch_select), "Batch for start/stop/restart/remove"),
+            (fixed("Esc"), "Clear the batch"),
+            (key(&k.toggle_stats_strip), "CPU strip for all containers"),
+            (key(&k.toggle_cpu_colors), "Absolute / relative CPU colours"),
+        ]),
+        ("Container actions", vec![
+            (key(&k.start), "Start"),
+            (key(&k.stop), "Stop"),
+            (key(&k.restart), "Restart"),
+            (key(&k.restart_follow), "Restart and follow the logs"),
+            (key(&k.kill), "Kill with a signal"),
+            (key(&k.delete), "Remove"),
+            (key(&k.rename), "Rename"),
+            (key(&k.shell), "Shell"),
+            (key(&k.exec_command), "Run a command"),
+            (key(&k.db_cli), "Database CLI"),
+            (key(&k.edit), "Edit"),
+            (key(&k.yaml), "YAML"),
+            (key(&k.export), "Export the filesystem"),
+            (key(&k.save_image), "Save the image"),
+            (key(&k.load_image), "Load an image"),
+            (key(&k.copy_id), "Copy the ID"),
+            (key(&k.copy_exec), "Copy a docker exec command"),
+            (key(&k.inspect), "View inspect JSON"),
+            (key(&k.copy_inspect), "Copy inspect JSON"),
+            (key(&k.dump_stats), "Dump a stats sample"),
+        ]),
+        ("Logs", vec![
+            (key(&k.toggle_log_follow), "Follow the newest lines"),
+            (fixed("PgUp PgDn"), "Page through the logs"),
+            (fixed("Home End"), "Oldest / newest line"),
+            (key(&k.search_logs), "Search (n/N step through hits)"),
+            (key(&k.toggle_log_wrap), "Wrap or cut long lines"),
+            (key(&k.toggle_line_numbers), "Line numbers"),
+            (key(&k.toggle_log_mode), "Stream / poll"),
+            (key(&k.cycle_log_refresh), "Poll interval"),
+            (key(&k.watch_file), "Watch a file in the container"),
+            (key(&k.copy_logs), "Copy the visible lines"),
+            (key(&k.save_logs), "Save the full log to a file"),
+        ]),
+        ("Details & env", vec![
+            (key(&k.enter), "Details / tools"),
+            (key(&k.toggle_env), "Environment only"),
+            (key(&k.filter_env), "Filter the environment"),
+            (key(&k.reveal_env), "Reveal / mask secrets"),
+        ]),
+    ]
+}
+
+// Centered over the dimmed screen; two columns when the terminal is wide enough
+pub fn draw(f: &mut Frame, app: &App, theme: &Theme) {
+    let size = f.size();
+    f.buffer_mut().set_style(size, Style::default().add_modifier(Modifier::DIM));
+    let area = super::centered_rect(90, 90, size);
+    f.render_widget(Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(
+            format!(" HELP ({} / Esc / q to close) ", app.config.keys.toggle_help),
+            Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD),
+        ))
+        .style(Style::default().bg(theme.background));
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let groups: Vec<Vec<Line>> = bindings(&app.config.keys)
+        .into_iter()
+        .map(|(title, keys)| {
+            let mut lines = vec![Line::from(Span::styled(title, Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))];
+            lines.extend(keys.into_iter().map(|(key, what)| {
+                Line::from(vec![
+                    Span::styled(format!(" {:>11}  ", key), Style::default().fg(theme.selection_bg).add_modifier(Modifier::BOLD)),
+                    Span::styled(what, Style::default().fg(theme.foreground)),
+                ])
+            }));
+            lines.push(Line::from(""));
+            lines
+        })
+        .collect();
+
+    // Whole groups per column, split where the first column reaches half the lines
+    let total: usize = groups.iter().map(|g| g.len()).sum();
+    let columns = if inner.width >= 90 { 2 } else { 1 };
+    let mut left = Vec::new();
+    let mut right = Vec::new();
+    for group in groups {
+        if columns == 2 && left.len() >= total / 2 {
+            right.extend(group);
+        } else {
+            left.extend(group);
+        }
+    }
+    let areas = Layout::default()
+        .direction(Direction::Horizontal)
+        .constraints([Constraint::Ratio(1, columns), Constraint::Ratio(columns - 1, columns)])
+        .split(inner);
+    f.render_widget(Paragraph::new(left), areas[0]);
+    if columns == 2 {
+        f.render_widget(Paragraph::new(right), areas[1]);
+    }
+}
@@ -0,0 +1,67 @@
+use ratatui::{
+    layout::{Constraint, Rect},
+    style::{Modifier, Style},
+    widgets::{Block, Borders, BorderType, Cell, Paragraph, Row, Table, TableState},
+    Frame,
+};
+use crate::app::{App, ImagesView};
+use crate::config::Theme;
+
+// Takes the container list's place while the images view is open, with the same table and cursor
+pub fn draw(f: &mut Frame, app: &App, view: &ImagesView, area: Rect, theme: &Theme) {
+    let title = match &view.images {
+        Some(images) => format!(" IMAGES ({}) | {} containers | {} refresh | d remove ", images.len(), app.config.keys.toggle_images, app.config.keys.refresh),
+        None => " IMAGES ".to_string(),
+    };
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Rounded)
+        .title(title);
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let images = match (&view.images, &view.error) {
+        (_, Some(e)) => {
+            f.render_widget(Paragraph::new(format!("Failed to list images: {}", e)).style(Style::default().fg(theme.stopped)), inner);
+            return;
+        }
+        (None, None) => {
+            let spinner = app.symbols.spinner[app.spinner_frame % app.symbols.spinner.len()];
+            f.render_widget(Paragraph::new(format!("{} Loading images…", spinner)).style(Style::default().fg(theme.border)), inner);
+            return;
+        }
+        (Some(images), None) => images,
+    };
+
+    let header = Row::new(["Repository:Tag", "ID", "Size", "Created"].iter().map(|h| {
+        Cell::from(*h).style(Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD))
+    }))
+    .style(Style::default().bg(theme.header_bg))
+    .height(1)
+    .bottom_margin(1);
+
+    let now = chrono::Utc::now().timestamp();
+    let rows = images.iter().map(|image| {
+        let label = image.label();
+        let untagged = label.ends_with(":<none>");
+        let age = std::time::Duration::from_secs((now - image.created).max(0) as u64);
+        Row::new(vec![
+            Cell::from(label).style(if untagged { Style::default().fg(theme.border) } else { Style::default() }),
+            Cell::from(image.short_id()),
+            Cell::from(app.units.format(image.size.max(0) as u64)),
+            Cell::from(format!("{} ago", super::util::format_age(age))),
+        ])
+        .style(Style::default().fg(theme.foreground))
+    });
+
+    let widths = [
+        Constraint::Percentage(50),
+        Constraint::Length(14),
+        Constraint::Length(12),
+        Constraint::Length(12),
+    ];
+    let t = Table::new(rows, widths).header(header).highlight_style(super::containers::selection_style(theme));
+    let mut state = TableState::default();
+    state.select(Some(view.selected));
+    f.render_stateful_widget(t, inner, &mut state);
+}
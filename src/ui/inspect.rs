@@ -0,0 +1,67 @@
+use ratatui::{
+    layout::{Constraint, Direction, Layout},
+    style::{Modifier, Style},
+    text::{Line, Span},
+    widgets::{Block, Borders, BorderType, Clear, Paragraph},
+    Frame,
+};
+use crate::app::{App, InspectView};
+use crate::config::Theme;
+
+// Full-screen `docker inspect` JSON; records its height so PgUp/PgDn page by a screenful
+pub fn draw(f: &mut Frame, app: &App, view: &mut InspectView, theme: &Theme) {
+    let area = f.size();
+    f.render_widget(Clear, area);
+
+    let name = app.all_containers.iter().find(|c| c.id == view.id).map(|c| app.container_label(c)).unwrap_or_else(|| app.id_text(&view.id));
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(format!(" INSPECT: {} ", name), Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))
+        .border_style(Style::default().fg(theme.selection_bg))
+        .style(Style::default().bg(theme.background));
+    let inner = block.inner(area);
+    f.render_widget(block, area);
+
+    let chunks = Layout::default()
+        .direction(Direction::Vertical)
+        .constraints([Constraint::Min(1), Constraint::Length(1)])
+        .split(inner);
+    view.height = chunks[0].height as usize;
+
+    let lines: Vec<Line> = match &view.lines {
+        None => vec![Line::from(Span::styled("Loading...", Style::default().fg(theme.border)))],
+        Some(Err(e)) => vec![Line::from(Span::styled(format!("Inspect failed: {}", e), Style::default().fg(theme.stopped)))],
+        Some(Ok(lines)) => lines
+            .iter()
+            .skip(view.scroll)
+            .take(view.height)
+            .map(|l| json_line(l, theme))
+            .collect(),
+    };
+    f.render_widget(Paragraph::new(lines).style(Style::default().fg(theme.foreground)), chunks[0]);
+
+    let position = match &view.lines {
+        Some(Ok(lines)) if !lines.is_empty() => format!("line {}/{} | ", (view.scroll + 1).min(lines.len()), lines.len()),
+        _ => String::new(),
+    };
+    let footer = Line::from(Span::styled(
+        format!("{}↑/↓ PgUp/PgDn Home/End: Scroll | Esc: Close", position),
+        Style::default().fg(theme.border),
+    ));
+    f.render_widget(Paragraph::new(footer), chunks[1]);
+}
+
+// Pretty-printed JSON has at most one key per line; set it apart from its value
+fn json_line<'a>(line: &'a str, theme: &Theme) -> Line<'a> {
+    let trimmed = line.trim_start();
+    let indent = &line[..line.len() - trimmed.len()];
+    match trimmed.strip_prefix('"').and_then(|rest| rest.find("\": ").map(|end| end + 3)) {
+        Some(end) => Line::from(vec![
+            Span::raw(indent),
+            Span::styled(&trimmed[..end], Style::default().fg(theme.header_fg)),
+            Span::raw(&trimmed[end..]),
+        ]),
+        None => Line::from(line),
+    }
+}
@@ -0,0 +1,83 @@
+use ratatui::layout::{Constraint, Direction, Layout, Rect};
+use crate::config::{GeneralConfig, MIN_PANEL_ROWS};
+
+// Where each panel goes this frame; None means the panel is hidden
+pub struct Areas {
+    pub monitor: Option<Rect>,
+    pub list: Option<Rect>,
+    pub side: Option<Rect>, // Tools or details
+    pub charts: Option<Rect>,
+    pub logs: Option<Rect>,
+    pub strip: Option<Rect>,
+    pub footer: Option<Rect>,
+}
+
+// Splits the screen according to `layout` ("default", "logs" or "list") minus `hidden_panels`.
+// Space of a hidden panel goes to its neighbour in the same row, or to the other row when a whole row is empty.
+// `zen` drops the monitor header and the footer so the panels get every row.
+// `list_width` is the list's percent of the middle row and `logs_height` the bottom row's percent
+// of the panel rows, both in the default layout.
+pub fn compute(general: &GeneralConfig, show_strip: bool, zen: bool, list_width: u16, logs_height: u16, size: Rect) -> Areas {
+    let shown = |panel: &str| !general.hidden_panels.iter().any(|p| p.eq_ignore_ascii_case(panel));
+
+    // Middle and bottom rows as (panel, share) from left to right
+    let (middle, bottom): (Vec<(&str, u16)>, Vec<(&str, u16)>) = match general.layout.as_str() {
+        // Narrow list next to full-height logs, no tools/details or charts
+        "logs" => (vec![("list", 30), ("logs", 70)], vec![]),
+        "list" => (vec![("list", 100)], vec![]),
+        _ => (vec![("list", list_width), ("side", 100 - list_width.min(100))], vec![("charts", 40), ("logs", 60)]),
+    };
+    let mut middle: Vec<(&str, u16)> = middle.into_iter().filter(|(p, _)| shown(p)).collect();
+    let bottom: Vec<(&str, u16)> = bottom.into_iter().filter(|(p, _)| shown(p)).collect();
+    if middle.is_empty() && bottom.is_empty() {
+        // Everything hidden: keep the list so the screen isn't blank
+        middle.push(("list", 100));
+    }
+
+    let monitor = shown("monitor") && !zen;
+    // Rows left for the panels once the fixed-height parts are placed
+    let panel_rows = size.height
+        .saturating_sub(if monitor { 10 } else { 0 })
+        .saturating_sub(if show_strip { 1 } else { 0 })
+        .saturating_sub(if zen { 0 } else { 3 });
+    let bottom_rows = (panel_rows as u32 * logs_height as u32 / 100) as u16;
+    let bottom_rows = bottom_rows.min(panel_rows.saturating_sub(MIN_PANEL_ROWS)).max(MIN_PANEL_ROWS);
+    let rows = Layout::default()
+        .direction(Direction::Vertical)
+        .constraints([
+            Constraint::Length(if monitor { 10 } else { 0 }),
+            Constraint::Min(if middle.is_empty() { 0 } else { MIN_PANEL_ROWS }),
+            // The bottom row takes the middle row's place when the middle row is empty
+            if middle.is_empty() { Constraint::Min(10) } else { Constraint::Length(if bottom.is_empty() { 0 } else { bottom_rows }) },
+            Constraint::Length(if show_strip { 1 } else { 0 }),
+            Constraint::Length(if zen { 0 } else { 3 }),
+        ])
+        .split(size);
+
+    let mut areas = Areas {
+        monitor: monitor.then(|| rows[0]),
+        list: None,
+        side: None,
+        charts: None,
+        logs: None,
+        strip: show_strip.then(|| rows[3]),
+        footer: (!zen).then(|| rows[4]),
+    };
+    for (row, panels) in [(rows[1], &middle), (rows[2], &bottom)] {
+        let total: u16 = panels.iter().map(|(_, pct)| pct).sum();
+        let cells = Layout::default()
+            .direction(Direction::Horizontal)
+            .constraints(panels.iter().map(|(_, pct)| Constraint::Ratio(*pct as u32, total.max(1) as u32)).collect::<Vec<_>>())
+            .split(row);
+        for (i, (panel, _)) in panels.iter().enumerate() {
+            let slot = match *panel {
+                "list" => &mut areas.list,
+                "side" => &mut areas.side,
+                "charts" => &mut areas.charts,
+                _ => &mut areas.logs,
+            };
+            *slot = Some(cells[i]);
+        }
+    }
+    areas
+}
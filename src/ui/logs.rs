@@ -1,31 +1,233 @@
 use ratatui::{
     layout::Rect,
-    style::Style,
+    style::{Modifier, Style},
     text::{Line, Span},
     widgets::{Block, Borders, BorderType, Paragraph, Wrap},
     Frame,
 };
-use crate::app::App;
+use crate::app::{App, LogStatus};
 use crate::config::Theme;
 
+// Longest part of a single log line that gets wrapped and drawn. A serialized blob of 100 KB
+// would otherwise become thousands of rows every frame. The stored line stays whole.
+const MAX_RENDERED_LINE: usize = 2000;
+
+fn capped(log: &str) -> std::borrow::Cow<'_, str> {
+    if log.len() <= MAX_RENDERED_LINE {
+        return std::borrow::Cow::Borrowed(log);
+    }
+    match log.char_indices().nth(MAX_RENDERED_LINE) {
+        Some((cut, _)) => std::borrow::Cow::Owned(format!("{} … [line truncated, {} bytes]", &log[..cut], log.len())),
+        None => std::borrow::Cow::Borrowed(log),
+    }
+}
+
 pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    let mut title = match &app.watching_file {
+        Some(path) => format!(" LOGS | tail -F {} ", path),
+        None => " LOGS ".to_string(),
+    };
+    if app.active_log_mode == crate::app::LogMode::Tail {
+        title.push_str(&format!("| last {} lines, stopped ", app.config.general.log_tail_lines));
+    } else if app.log_follow {
+        title.push_str("| FOLLOW ");
+    }
+    if !app.log_search.is_empty() {
+        title.push_str(&format!("| search: {} ({} matches, n/N) ", app.log_search, app.log_match_count()));
+    }
     let block = Block::default()
         .borders(Borders::ALL)
         .border_type(BorderType::Rounded)
-        .title(" LOGS ");
+        .title(title);
     
     let inner = block.inner(area);
     f.render_widget(block, area);
 
-    let logs: Vec<Line> = app.logs
+    if app.logs.is_empty() {
+        draw_empty(f, app, inner, theme);
+        return;
+    }
+
+    if app.show_line_numbers {
+        draw_numbered(f, app, inner, theme);
+        return;
+    }
+
+    let width = (inner.width as usize).max(1);
+    let skip = first_line(app, width, inner.height as usize);
+
+    let mut rows: Vec<Line> = Vec::new();
+    for (stderr, log) in app.logs.iter().skip(skip) {
+        let style = stream_style(*stderr, theme);
+        if !app.log_wrap {
+            rows.push(Line::from(highlighted(&super::util::truncate_ellipsis(log.trim(), width), app, style, theme)));
+            continue;
+        }
+        // Cut by characters, the way last_page_start counts rows, rather than at word boundaries,
+        // which can take more rows and push the newest lines below the panel. Highlighted per row
+        // like draw_numbered.
+        let chars: Vec<char> = capped(log).trim().chars().collect();
+        if chars.is_empty() {
+            rows.push(Line::from(""));
+        }
+        for chunk in chars.chunks(width) {
+            rows.push(Line::from(highlighted(&chunk.iter().collect::<String>(), app, style, theme)));
+        }
+    }
+
+    // A single line taller than the panel still ends on the bottom row when following
+    if app.log_follow {
+        let skip = rows.len().saturating_sub(inner.height as usize);
+        rows.drain(..skip);
+    }
+
+    let p = Paragraph::new(rows).style(Style::default().fg(theme.foreground));
+    f.render_widget(p, inner);
+}
+
+// stderr in the stopped color so errors stand out from regular output
+fn stream_style(stderr: bool, theme: &Theme) -> Style {
+    if stderr {
+        Style::default().fg(theme.stopped)
+    } else {
+        Style::default().fg(theme.foreground)
+    }
+}
+
+// Columns left for the text after the line-number gutter, when one is shown
+fn text_width(app: &App, width: usize) -> usize {
+    if app.show_line_numbers {
+        let gutter = app.logs.len().max(1).to_string().len();
+        width.saturating_sub(gutter + 1).max(1)
+    } else {
+        width.max(1)
+    }
+}
+
+// Oldest line that still lets the newest ones fit after wrapping. Following starts there, and
+// scrolling can't go past it into empty space. A single line taller than the panel still shows.
+pub fn last_page_start(app: &App, width: usize, height: usize) -> usize {
+    let width = text_width(app, width);
+    let mut start = app.logs.len();
+    let mut used = 0;
+    for (_, log) in app.logs.iter().rev() {
+        let rows = if app.log_wrap { (capped(log).trim().chars().count().max(1) + width - 1) / width } else { 1 };
+        if used + rows > height { break; }
+        used += rows;
+        start -= 1;
+    }
+    start.min(app.logs.len().saturating_sub(1))
+}
+
+fn first_line(app: &App, width: usize, height: usize) -> usize {
+    let last = last_page_start(app, width, height);
+    if app.log_follow { last } else { app.log_scroll.min(last) }
+}
+
+// The log lines on screen as of the last draw, whole even when only part of one fits
+pub fn visible_lines(app: &App) -> Vec<&str> {
+    let (width, height) = app.log_area;
+    let text_width = text_width(app, width);
+    let mut used = 0;
+    app.logs
         .iter()
-        .map(|log| Line::from(Span::raw(log)))
-        .collect();
+        .skip(first_line(app, width, height))
+        .take_while(|(_, log)| {
+            let fits = used < height.max(1);
+            used += if app.log_wrap { (capped(log).trim().chars().count().max(1) + text_width - 1) / text_width } else { 1 };
+            fits
+        })
+        .map(|(_, log)| log.trim_end())
+        .collect()
+}
+
+// Splits the text so every case-insensitive hit of the log search stands out
+fn highlighted(text: &str, app: &App, base: Style, theme: &Theme) -> Vec<Span<'static>> {
+    if app.log_search.is_empty() {
+        return vec![Span::styled(text.to_string(), base)];
+    }
+    // ASCII lowercasing keeps byte offsets identical, so they index the original text
+    let haystack = text.to_ascii_lowercase();
+    let needle = app.log_search.to_ascii_lowercase();
+    let hit = Style::default().fg(theme.background).bg(theme.restarting).add_modifier(Modifier::BOLD);
+    let mut spans = Vec::new();
+    let mut last = 0;
+    for (start, _) in haystack.match_indices(&needle) {
+        if start < last || !text.is_char_boundary(start) {
+            continue;
+        }
+        if start > last {
+            spans.push(Span::styled(text[last..start].to_string(), base));
+        }
+        spans.push(Span::styled(text[start..start + needle.len()].to_string(), hit));
+        last = start + needle.len();
+    }
+    if last < text.len() || spans.is_empty() {
+        spans.push(Span::styled(text[last..].to_string(), base));
+    }
+    spans
+}
 
-    let p = Paragraph::new(logs)
+// Wraps manually so continuation lines stay aligned after the line-number gutter
+fn draw_numbered(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    let gutter = app.logs.len().max(1).to_string().len();
+    let text_width = text_width(app, area.width as usize);
+    let number_style = Style::default().fg(theme.border);
+
+    let mut lines: Vec<Line> = Vec::new();
+    let first_line = first_line(app, area.width as usize, area.height as usize);
+    let mut first_row = 0;
+    for (i, (stderr, log)) in app.logs.iter().enumerate() {
+        if i == first_line {
+            first_row = lines.len();
+        }
+        let text_style = stream_style(*stderr, theme);
+        let chars: Vec<char> = capped(log).trim_end().chars().collect();
+        if !app.log_wrap {
+            let text = super::util::truncate_ellipsis(&chars.iter().collect::<String>(), text_width);
+            let mut spans = vec![Span::styled(format!("{:>width$} ", i + 1, width = gutter), number_style)];
+            spans.extend(highlighted(&text, app, text_style, theme));
+            lines.push(Line::from(spans));
+            continue;
+        }
+        let mut chunks = chars.chunks(text_width);
+        let first: String = chunks.next().map(|c| c.iter().collect()).unwrap_or_default();
+        // Highlighted per row, so a hit split across a wrap is left plain
+        let mut first_spans = vec![Span::styled(format!("{:>width$} ", i + 1, width = gutter), number_style)];
+        first_spans.extend(highlighted(&first, app, text_style, theme));
+        lines.push(Line::from(first_spans));
+        for chunk in chunks {
+            let mut spans = vec![Span::raw(" ".repeat(gutter + 1))];
+            spans.extend(highlighted(&chunk.iter().collect::<String>(), app, text_style, theme));
+            lines.push(Line::from(spans));
+        }
+    }
+
+    // Following keeps the bottom row filled even when that cuts into the top line's wrap
+    if app.log_follow {
+        let skip = lines.len().saturating_sub(area.height as usize);
+        lines.drain(..skip);
+    } else {
+        lines.drain(..first_row);
+    }
+
+    let p = Paragraph::new(lines).style(Style::default().fg(theme.foreground));
+    f.render_widget(p, area);
+}
+
+// Say why there is nothing to show rather than leaving the panel blank
+fn draw_empty(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    let stopped = app.get_selected_container().map(|c| c.state != "running").unwrap_or(false);
+    let (msg, color) = match &app.log_status {
+        _ if app.get_selected_container().is_none() => ("No container selected".to_string(), theme.border),
+        LogStatus::Loading => ("Loading logs…".to_string(), theme.border),
+        LogStatus::Connected(_) if stopped => ("Container is stopped and produced no logs".to_string(), theme.border),
+        LogStatus::Connected(_) => ("No output yet".to_string(), theme.border),
+        LogStatus::Failed(e) => (format!("Failed to fetch logs: {}", e), theme.stopped),
+        LogStatus::Gone => ("Container is gone".to_string(), theme.border),
+    };
+    let p = Paragraph::new(msg)
         .wrap(Wrap { trim: true })
-        .style(Style::default().fg(theme.foreground));
-        //.scroll((app.scroll_offset as u16, 0));
-    
-    f.render_widget(p, inner);
+        .style(Style::default().fg(color));
+    f.render_widget(p, area);
 }
@@ -5,12 +5,21 @@ pub mod logs;
 pub mod footer;
 pub mod tools;
 pub mod util;
+pub mod exec;
+pub mod events;
+pub mod details;
+pub mod compare;
+pub mod images;
+pub mod strip;
+pub mod layout;
+pub mod help;
+pub mod inspect;
 
 pub use util::calculate_cpu_usage;
 
 use ratatui::{
     layout::{Constraint, Direction, Layout, Rect},
-    widgets::{Block, Borders, List, ListItem, Paragraph, Wrap},
+    widgets::{Block, Borders, BorderType, List, ListItem, Paragraph, Wrap},
     style::{Style, Modifier},
     text::{Line, Span},
     Frame,
@@ -20,43 +29,51 @@ use crate::config::Theme;
 
 pub fn draw(f: &mut Frame, app: &mut App) {
     let theme = &app.config.theme_data;
-    let chunks = Layout::default()
-        .direction(Direction::Vertical)
-        .constraints([
-            Constraint::Length(10), // Monitor (CPU, Mem, Net)
-            Constraint::Min(10),    // Main Content (Containers + Tools)
-            Constraint::Length(10), // Bottom (Charts + Logs)
-            Constraint::Length(3),  // Footer (Management + Shortcuts)
-        ])
-        .split(f.size());
-
-    // 1. Top Monitor Panel
-    monitor::draw(f, app, chunks[0], theme);
-
-    // 2. Main Content (Containers + Tools)
-    let main_chunks = Layout::default()
-        .direction(Direction::Horizontal)
-        .constraints([
-            Constraint::Percentage(60), // Containers
-            Constraint::Percentage(40), // Tools
-        ])
-        .split(chunks[1]);
+    // 0. Until the first container list arrives there is nothing to lay out, so the whole
+    // screen says what it's waiting for; overlays still draw on top
+    if !app.initialized {
+        draw_connecting(f, app, theme);
+    } else {
+        let areas = layout::compute(&app.config.general, app.show_stats_strip, app.zen, app.list_width, app.logs_height, f.size());
 
-    containers::draw(f, app, main_chunks[0], theme);
-    tools::draw(f, app, main_chunks[1], theme);
+        // 1. Top Monitor Panel
+        if let Some(area) = areas.monitor {
+            monitor::draw(f, app, area, theme);
+        }
 
-    // 3. Bottom Content (Charts + Logs)
-    let bottom_chunks = Layout::default()
-        .direction(Direction::Horizontal)
-        .constraints([
-            Constraint::Percentage(40), // Charts (Net Traffic, Storage IO)
-            Constraint::Percentage(60), // Logs
-        ])
-        .split(chunks[2]);
+        // 2. Main Content (Containers + Tools)
+        if let Some(area) = areas.list {
+            match &app.images_view {
+                Some(view) => images::draw(f, app, view, area, theme),
+                None => containers::draw(f, app, area, theme),
+            }
+        }
+        if let Some(area) = areas.side {
+            if app.env_view {
+                app.env_area_height = area.height.saturating_sub(2) as usize;
+                details::draw_env(f, app, area, theme);
+            } else if app.show_details {
+                details::draw(f, app, area, theme);
+            } else {
+                tools::draw(f, app, area, theme);
+            }
+        }
 
-    charts::draw(f, app, bottom_chunks[0], theme);
-    logs::draw(f, app, bottom_chunks[1], theme);
-    footer::draw(f, app, chunks[3], theme);
+        // 3. Bottom Content (Charts + Logs)
+        if let Some(area) = areas.charts {
+            charts::draw(f, app, area, theme);
+        }
+        if let Some(area) = areas.logs {
+            app.log_area = (area.width.saturating_sub(2) as usize, area.height.saturating_sub(2) as usize);
+            logs::draw(f, app, area, theme);
+        }
+        if let Some(area) = areas.strip {
+            strip::draw(f, app, area, theme);
+        }
+        if let Some(area) = areas.footer {
+            footer::draw(f, app, area, theme);
+        }
+    }
 
     // 5. Wizard Overlay (Focus Mode)
     if let Some(wizard) = &app.wizard {
@@ -65,11 +82,64 @@ pub fn draw(f: &mut Frame, app: &mut App) {
         draw_wizard(f, wizard, area, theme);
     }
 
+    // 5b. Text Prompt / Exec Output Overlay
+    if let Some(prompt) = &app.prompt {
+        let input = match prompt.kind {
+            crate::app::PromptKind::Exec => format!("$ {}_", prompt.input),
+            _ => format!("{}_", prompt.input),
+        };
+        exec::draw_prompt(f, prompt.title(), &input, theme);
+    } else if let Some(view) = &app.exec_view {
+        exec::draw_output(f, view, theme);
+    }
+
+    // 5c. Daemon Events View
+    if app.events_view.is_some() {
+        events::draw(f, app, theme);
+    }
+
+    // 5d. Compare View
+    if let Some(view) = &app.compare_view {
+        compare::draw(f, app, view, theme);
+    }
+
+    // 5c'. Inspect JSON
+    if let Some(mut view) = app.inspect_view.take() {
+        inspect::draw(f, app, &mut view, theme);
+        app.inspect_view = Some(view);
+    }
+
+    // 5d'. Last Error
+    if let (Some(scroll), Some(error)) = (app.error_view, &app.last_error) {
+        draw_last_error(f, error, scroll, theme);
+    }
+
+    // 5d''. Kill Signal Menu
+    if let Some(menu) = &app.signal_menu {
+        draw_signal_menu(f, app, menu, theme);
+    }
+
+    // 5d'''. Prune Menu
+    if let Some(menu) = &app.prune_menu {
+        draw_prune_menu(f, app, menu, theme);
+    }
+
+    // 5d''''. Key Help
+    if app.show_help {
+        help::draw(f, app, theme);
+    }
+
+    // 5e. Confirmation Dialog (always on top)
+    if let Some(confirm) = &app.confirm {
+        draw_confirm(f, confirm, theme);
+    }
+
     // 6. Toast Notifications (Top-Right)
     if let Some((msg, time)) = &app.action_status {
-        if time.elapsed().as_secs() < 5 {
+        if time.elapsed() < crate::app::STATUS_TTL {
             let toast_width = 40;
-            let toast_height = 3;
+            // Grows for the multi-line summary of a run of queued actions
+            let toast_height = msg.lines().count().clamp(1, 6) as u16 + 2;
             let size = f.size();
             let area = Rect::new(
                 size.width.saturating_sub(toast_width + 2), // Top Right with padding
@@ -97,6 +167,26 @@ pub fn draw(f: &mut Frame, app: &mut App) {
     }
 }
 
+fn draw_connecting(f: &mut Frame, app: &App, theme: &Theme) {
+    let chunks = Layout::default()
+        .direction(Direction::Vertical)
+        .constraints([Constraint::Percentage(40), Constraint::Length(5), Constraint::Min(0)])
+        .split(f.size());
+    let spinner = app.symbols.spinner[app.spinner_frame % app.symbols.spinner.len()];
+    let host = app.host_label().unwrap_or_else(|| crate::docker::daemon_host().label());
+    let mut text = vec![
+        Line::from(Span::styled(format!("{} Connecting to Docker…", spinner), Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD))),
+        Line::from(Span::styled(host, Style::default().fg(theme.border))),
+    ];
+    // The first attempt failed; keep retrying, but say why
+    if let Some(reason) = &app.daemon_down {
+        text.push(Line::from(""));
+        text.push(Line::from(Span::styled(reason.clone(), Style::default().fg(theme.stopped))));
+    }
+    text.push(Line::from(Span::styled(format!("{} to quit", app.config.keys.quit), Style::default().fg(theme.border))));
+    f.render_widget(Paragraph::new(text).alignment(ratatui::layout::Alignment::Center).wrap(Wrap { trim: true }), chunks[1]);
+}
+
 // Helper to center rect
 fn centered_rect(percent_x: u16, percent_y: u16, r: Rect) -> Rect {
     let popup_layout = Layout::default()
@@ -120,6 +210,123 @@ fn centered_rect(percent_x: u16, percent_y: u16, r: Rect) -> Rect {
 
 
 
+fn draw_signal_menu(f: &mut Frame, app: &App, menu: &crate::app::SignalMenu, theme: &Theme) {
+    let area = centered_rect(30, 30, f.size());
+    f.render_widget(ratatui::widgets::Clear, area);
+
+    let name = app.containers.iter().find(|c| c.id == menu.id).map(|c| app.container_label(c)).unwrap_or_else(|| app.id_text(&menu.id));
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(format!(" Kill {} ", name), Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))
+        .border_style(Style::default().fg(theme.stopped))
+        .style(Style::default().bg(theme.background));
+
+    let mut lines: Vec<Line> = crate::app::KILL_SIGNALS
+        .iter()
+        .enumerate()
+        .map(|(i, signal)| {
+            let text = format!(" {} {} ", i + 1, signal);
+            if i == menu.selected {
+                Line::from(Span::styled(text, Style::default().fg(theme.selection_fg).bg(theme.selection_bg).add_modifier(Modifier::BOLD)))
+            } else {
+                Line::from(Span::raw(text))
+            }
+        })
+        .collect();
+    lines.push(Line::from(""));
+    lines.push(Line::from(Span::styled("Enter send | Esc cancel", Style::default().fg(theme.border))));
+
+    let p = Paragraph::new(lines).block(block).style(Style::default().fg(theme.foreground));
+    f.render_widget(p, area);
+}
+
+fn draw_prune_menu(f: &mut Frame, app: &App, menu: &crate::app::PruneMenu, theme: &Theme) {
+    let area = centered_rect(40, 30, f.size());
+    f.render_widget(ratatui::widgets::Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(" Prune ", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))
+        .border_style(Style::default().fg(theme.stopped))
+        .style(Style::default().bg(theme.background));
+
+    let estimate = match &menu.estimate {
+        Some(Ok(e)) => [Some(e.containers), Some(e.images)],
+        _ => [None, None],
+    };
+    let rows = [("Stopped containers", menu.containers), ("Dangling images", menu.images)];
+    let mut lines: Vec<Line> = rows
+        .iter()
+        .zip(estimate)
+        .enumerate()
+        .map(|(i, ((label, picked), counted))| {
+            let size = match (counted, &menu.estimate) {
+                (Some((n, bytes)), _) => format!("{} | {}", n, app.units.format(bytes)),
+                (None, None) => "estimating...".to_string(),
+                (None, Some(_)) => "size unknown".to_string(),
+            };
+            let text = format!(" {} [{}] {}  ({}) ", i + 1, if *picked { "x" } else { " " }, label, size);
+            if i == menu.selected {
+                Line::from(Span::styled(text, Style::default().fg(theme.selection_fg).bg(theme.selection_bg).add_modifier(Modifier::BOLD)))
+            } else {
+                Line::from(Span::raw(text))
+            }
+        })
+        .collect();
+    lines.push(Line::from(""));
+    lines.push(Line::from(Span::styled("Space toggle | Enter prune | Esc cancel", Style::default().fg(theme.border))));
+
+    let p = Paragraph::new(lines).block(block).style(Style::default().fg(theme.foreground));
+    f.render_widget(p, area);
+}
+
+fn draw_confirm(f: &mut Frame, confirm: &crate::app::Confirm, theme: &Theme) {
+    let area = centered_rect(50, 30, f.size());
+    f.render_widget(ratatui::widgets::Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(confirm.title.clone(), Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))
+        .border_style(Style::default().fg(theme.restarting))
+        .style(Style::default().bg(theme.background));
+
+    let p = Paragraph::new(confirm.message.clone())
+        .block(block)
+        .wrap(Wrap { trim: false })
+        .style(Style::default().fg(theme.foreground));
+    f.render_widget(p, area);
+}
+
+fn draw_last_error(f: &mut Frame, error: &crate::app::LastError, scroll: u16, theme: &Theme) {
+    let area = centered_rect(70, 60, f.size());
+    f.render_widget(ratatui::widgets::Clear, area);
+
+    let block = Block::default()
+        .borders(Borders::ALL)
+        .border_type(BorderType::Thick)
+        .title(Span::styled(" LAST ERROR (j/k scroll, Esc close) ", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)))
+        .border_style(Style::default().fg(theme.stopped))
+        .style(Style::default().bg(theme.background));
+
+    let label = Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD);
+    let mut lines = vec![
+        Line::from(vec![Span::styled("Operation: ", label), Span::raw(error.operation.clone())]),
+        Line::from(vec![Span::styled("At:        ", label), Span::raw(error.at.format("%Y-%m-%d %H:%M:%S").to_string())]),
+        Line::from(""),
+    ];
+    lines.extend(error.message.lines().map(|l| Line::from(l.to_string())));
+
+    let p = Paragraph::new(lines)
+        .block(block)
+        .wrap(Wrap { trim: false })
+        .scroll((scroll, 0))
+        .style(Style::default().fg(theme.foreground));
+    f.render_widget(p, area);
+}
+
 // Re-implement draw_wizard here or move it to a separate module if it gets too large
 // For now, keeping it here as it was in the original ui.rs, but updated to use the new style
 fn draw_wizard(f: &mut Frame, wizard: &crate::wizard::models::WizardState, area: Rect, theme: &Theme) {
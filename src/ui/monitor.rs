@@ -8,13 +8,35 @@ use ratatui::{
 use crate::app::App;
 use crate::config::Theme;
 use sysinfo::System;
+use super::util::truncate_ellipsis;
 
 pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    // Show which runtime we are talking to (Docker vs Podman) once it has been detected
+    let mut title = match &app.server_info {
+        Some(info) => match &info.version {
+            Some(version) => format!(" SYSTEM DASHBOARD | {} {} ", info.runtime_name(), version),
+            None => format!(" SYSTEM DASHBOARD | {} ", info.runtime_name()),
+        },
+        None => " SYSTEM DASHBOARD ".to_string(),
+    };
+    let suffix = if app.list_all { "" } else { "| stopped containers hidden " };
+
+    // The host is the only open-ended part, so it absorbs the shortfall on narrow terminals
+    if let Some(host) = app.host_label() {
+        let budget = (area.width as usize).saturating_sub(title.chars().count() + suffix.chars().count() + 6);
+        if budget > 0 {
+            title.push_str(&format!("| {} ", truncate_ellipsis(&host, budget)));
+        }
+    }
+    title.push_str(suffix);
+    let title = truncate_ellipsis(&title, (area.width as usize).saturating_sub(2));
+
+    let border_color = if app.resource_alert().is_some() { theme.stopped } else { theme.header_fg };
     let block = Block::default()
         .borders(Borders::ALL)
         .border_type(BorderType::Thick)
-        .border_style(Style::default().fg(theme.header_fg))
-        .title(Span::styled(" SYSTEM DASHBOARD ", Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)));
+        .border_style(Style::default().fg(border_color))
+        .title(Span::styled(title, Style::default().fg(theme.header_fg).add_modifier(Modifier::BOLD)));
     
     let inner = block.inner(area);
     f.render_widget(block, area);
@@ -76,7 +98,7 @@ fn draw_memory_panel(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
         .block(Block::default().borders(Borders::NONE))
         .gauge_style(Style::default().fg(theme.memory_chart).bg(theme.header_bg))
         .ratio(mem_ratio)
-        .label(format!("{:.1}GB / {:.1}GB", used_mem as f64 / 1024.0_f64.powi(3), total_mem as f64 / 1024.0_f64.powi(3)));
+        .label(format!("{} / {}", app.units.format(used_mem), app.units.format(total_mem)));
     f.render_widget(ram_gauge, chunks[1]);
 
     // Swap / Storage (Mocked relative to swap for visual balance)
@@ -89,7 +111,7 @@ fn draw_memory_panel(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
         .block(Block::default().borders(Borders::NONE))
         .gauge_style(Style::default().fg(theme.chart_mid).bg(theme.header_bg))
         .ratio(swap_ratio)
-        .label(format!("{:.1}GB / {:.1}GB", used_swap as f64 / 1024.0_f64.powi(3), total_swap as f64 / 1024.0_f64.powi(3)));
+        .label(format!("{} / {}", app.units.format(used_swap), app.units.format(total_swap)));
     f.render_widget(swap_gauge, chunks[4]);
 }
 
@@ -105,8 +127,8 @@ fn draw_network_panel(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
     f.render_widget(Paragraph::new("Network & IO").style(Style::default().fg(theme.network_rx).add_modifier(Modifier::BOLD)), chunks[0]);
 
     let text = vec![
-        Line::from(vec![Span::styled("⬇ RX Stream: ", Style::default().fg(theme.network_rx)), Span::raw(format!("{:.1} kB/s", rx))]),
-        Line::from(vec![Span::styled("⬆ TX Stream: ", Style::default().fg(theme.network_tx)), Span::raw(format!("{:.1} kB/s", tx))]),
+        Line::from(vec![Span::styled(format!("{} RX Stream: ", app.symbols.rx), Style::default().fg(theme.network_rx)), Span::raw(format!("{}/s", app.units.format(rx as u64)))]),
+        Line::from(vec![Span::styled(format!("{} TX Stream: ", app.symbols.tx), Style::default().fg(theme.network_tx)), Span::raw(format!("{}/s", app.units.format(tx as u64)))]),
         Line::from(""),
         Line::from(vec![Span::raw("Uptime: "), Span::styled(format!("{} s", System::uptime()), Style::default().fg(theme.foreground))]),
     ];
@@ -0,0 +1,82 @@
+use ratatui::{
+    layout::Rect,
+    style::{Modifier, Style},
+    text::{Line, Span},
+    widgets::Paragraph,
+    Frame,
+};
+use crate::app::App;
+use crate::config::Theme;
+
+// One line of "name cpu% load" cells for every running (or paused) container. When they don't fit,
+// the strip scrolls so the selected container stays in view.
+pub fn draw(f: &mut Frame, app: &App, area: Rect, theme: &Theme) {
+    let running: Vec<&crate::docker::Container> = app.containers.iter().filter(|c| c.state == "running" || c.state == "paused").collect();
+    if running.is_empty() {
+        let p = Paragraph::new(" No running containers").style(Style::default().fg(theme.border));
+        f.render_widget(p, area);
+        return;
+    }
+
+    let selected_id = app.get_selected_container().map(|c| c.id.as_str());
+    let cells: Vec<(Vec<Span>, usize)> = running.iter().map(|c| {
+        let name = c.names.first().map(|n| n.trim_start_matches('/')).unwrap_or("");
+        let name: String = name.chars().take(16).collect();
+        let (cpu_text, color, level) = match app.strip_stats.get(&c.id) {
+            _ if c.state == "paused" => ("paused".to_string(), theme.restarting, None),
+            _ if app.strip_missing.contains(&c.id) => ("stats?".to_string(), theme.restarting, None),
+            Some((_, cpu)) => {
+                let color = super::util::heat_color(app.cpu_heat(*cpu), theme);
+                let level = ((cpu.clamp(0.0, 100.0) / 100.0) * (app.symbols.load.len() - 1) as f64).round() as usize;
+                (format!("{:.0}%", cpu), color, Some(level))
+            }
+            None => ("--".to_string(), theme.border, None),
+        };
+        let load = level.map(|l| app.symbols.load[l]).unwrap_or(" ");
+
+        let name_style = if Some(c.id.as_str()) == selected_id {
+            Style::default().fg(theme.selection_fg).bg(theme.selection_bg).add_modifier(Modifier::BOLD)
+        } else {
+            Style::default().fg(theme.foreground)
+        };
+        let width = name.chars().count() + cpu_text.len() + 5;
+        (vec![
+            Span::raw(" "),
+            Span::styled(name, name_style),
+            Span::raw(" "),
+            Span::styled(cpu_text, Style::default().fg(color)),
+            Span::raw(" "),
+            Span::styled(load, Style::default().fg(color).add_modifier(Modifier::BOLD)),
+            Span::styled(" │", Style::default().fg(theme.border)),
+        ], width)
+    }).collect();
+
+    // Start as far left as possible while still ending on the selected cell
+    let available = area.width as usize;
+    let focus = running.iter().position(|c| Some(c.id.as_str()) == selected_id).unwrap_or(0);
+    let mut start = 0;
+    while start < focus && cells[start..=focus].iter().map(|(_, w)| w).sum::<usize>() + 2 > available {
+        start += 1;
+    }
+
+    let mut spans = Vec::new();
+    if start > 0 {
+        spans.push(Span::styled("‹", Style::default().fg(theme.header_fg)));
+    }
+    let mut used = if start > 0 { 1 } else { 0 };
+    let mut shown = start;
+    for (cell, width) in &cells[start..] {
+        // Leave room for the "› +N" overflow marker
+        if used + width > available.saturating_sub(6) && shown > start {
+            break;
+        }
+        spans.extend(cell.iter().cloned());
+        used += width;
+        shown += 1;
+    }
+    if shown < cells.len() {
+        spans.push(Span::styled(format!(" › +{}", cells.len() - shown), Style::default().fg(theme.header_fg)));
+    }
+
+    f.render_widget(Paragraph::new(Line::from(spans)), area);
+}
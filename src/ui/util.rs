@@ -1,6 +1,19 @@
 use crate::docker::ContainerStats;
+use crate::config::Theme;
+
+// Green / yellow / red for a heat level from App::cpu_heat or app::heat
+pub fn heat_color(heat: u8, theme: &Theme) -> ratatui::style::Color {
+    match heat {
+        2 => theme.stopped,
+        1 => theme.restarting,
+        _ => theme.running,
+    }
+}
 
 pub fn calculate_cpu_usage(stats: &ContainerStats, previous_stats: &Option<ContainerStats>) -> f64 {
+    if stats.is_windows() {
+        return windows_cpu_usage(stats);
+    }
     let mut cpu_percent = 0.0;
     
     if let Some(prev) = previous_stats {
@@ -15,3 +28,138 @@ pub fn calculate_cpu_usage(stats: &ContainerStats, previous_stats: &Option<Conta
     
     cpu_percent
 }
+
+// Windows has no system_cpu_usage: usage is in 100ns intervals, measured against the wall time
+// between the two readings of the same sample times the container's processors (as `docker stats`)
+fn windows_cpu_usage(stats: &ContainerStats) -> f64 {
+    let parse = |t: &Option<String>| t.as_deref().and_then(|t| chrono::DateTime::parse_from_rfc3339(t).ok());
+    let (Some(read), Some(preread)) = (parse(&stats.read), parse(&stats.preread)) else {
+        return 0.0;
+    };
+    let intervals = (read - preread).num_nanoseconds().unwrap_or(0) as f64 / 100.0 * stats.num_procs.unwrap_or(0) as f64;
+    let used = stats.cpu_stats.cpu_usage.total_usage as f64 - stats.precpu_stats.cpu_usage.total_usage as f64;
+    if intervals > 0.0 && used > 0.0 {
+        used / intervals * 100.0
+    } else {
+        0.0
+    }
+}
+
+// Cuts `s` to at most `max` characters, marking the cut with an ellipsis
+pub fn truncate_ellipsis(s: &str, max: usize) -> String {
+    if s.chars().count() <= max {
+        return s.to_string();
+    }
+    if max == 0 {
+        return String::new();
+    }
+    let mut out: String = s.chars().take(max - 1).collect();
+    out.push('…');
+    out
+}
+
+// Cuts `s` to at most `max` characters by dropping its middle, so both ends of a path stay readable
+pub fn truncate_middle(s: &str, max: usize) -> String {
+    let count = s.chars().count();
+    if count <= max {
+        return s.to_string();
+    }
+    if max == 0 {
+        return String::new();
+    }
+    let tail = (max - 1) / 2;
+    let head = max - 1 - tail;
+    let mut out: String = s.chars().take(head).collect();
+    out.push('…');
+    out.extend(s.chars().skip(count - tail));
+    out
+}
+
+// One-line block chart of `values` scaled to the window's min/max. Only the newest values that
+// fit in `width` are drawn; fewer than that are right-aligned behind blank padding.
+pub fn sparkline_text(values: &[f64], width: usize) -> String {
+    const BLOCKS: [char; 8] = ['▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'];
+    let shown = &values[values.len().saturating_sub(width)..];
+    let min = shown.iter().copied().fold(f64::INFINITY, f64::min);
+    let max = shown.iter().copied().fold(f64::NEG_INFINITY, f64::max);
+    let range = max - min;
+    let mut out = " ".repeat(width - shown.len());
+    for v in shown {
+        // A flat window sits on the bottom row instead of dividing by zero
+        let level = if range > 0.0 { ((v - min) / range * 7.0).round() as usize } else { 0 };
+        out.push(BLOCKS[level.min(7)]);
+    }
+    out
+}
+
+// Compact age with its two largest units, like "30s", "5m12s", "3h12m" or "2d4h"
+pub fn format_age(age: std::time::Duration) -> String {
+    let secs = age.as_secs();
+    let two = |big: u64, big_unit: &str, small: u64, small_unit: &str| {
+        if small == 0 { format!("{}{}", big, big_unit) } else { format!("{}{}{}{}", big, big_unit, small, small_unit) }
+    };
+    match secs {
+        0..=59 => format!("{}s", secs),
+        60..=3599 => two(secs / 60, "m", secs % 60, "s"),
+        3600..=86399 => two(secs / 3600, "h", secs % 3600 / 60, "m"),
+        _ => two(secs / 86400, "d", secs % 86400 / 3600, "h"),
+    }
+}
+
+// How byte counts are shown: a fixed unit or the largest that fits, in binary (MiB, like
+// `docker stats`) or decimal (MB) steps. The suffix always says which base is in use.
+#[derive(Clone, Copy, Debug)]
+pub struct ByteUnits {
+    fixed: Option<u32>, // Exponent of the unit to always use (2 = mega, 3 = giga)
+    binary: bool,
+}
+
+impl ByteUnits {
+    // units: "auto", "mb" or "gb"; base: "binary" or "decimal"
+    pub fn from_config(units: &str, base: &str) -> Self {
+        let fixed = match units.to_lowercase().as_str() {
+            "mb" | "mib" => Some(2),
+            "gb" | "gib" => Some(3),
+            _ => None,
+        };
+        Self { fixed, binary: base != "decimal" }
+    }
+
+    pub fn format(&self, bytes: u64) -> String {
+        let step: f64 = if self.binary { 1024.0 } else { 1000.0 };
+        let b = bytes as f64;
+        let exp = self.fixed.unwrap_or_else(|| match b {
+            b if b >= step.powi(3) => 3,
+            b if b >= step.powi(2) => 2,
+            _ => 1,
+        });
+        let suffix = match (exp, self.binary) {
+            (3, true) => "GiB",
+            (2, true) => "MiB",
+            (_, true) => "KiB",
+            (3, false) => "GB",
+            (2, false) => "MB",
+            (_, false) => "kB",
+        };
+        format!("{:.1} {}", b / step.powi(exp as i32), suffix)
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::format_age;
+    use std::time::Duration;
+
+    #[test]
+    fn format_age_unit_boundaries() {
+        assert_eq!(format_age(Duration::from_secs(0)), "0s");
+        assert_eq!(format_age(Duration::from_secs(59)), "59s");
+        assert_eq!(format_age(Duration::from_secs(60)), "1m");
+        assert_eq!(format_age(Duration::from_secs(61)), "1m1s");
+        assert_eq!(format_age(Duration::from_secs(59 * 60 + 59)), "59m59s");
+        assert_eq!(format_age(Duration::from_secs(3600)), "1h");
+        assert_eq!(format_age(Duration::from_secs(23 * 3600 + 59 * 60)), "23h59m");
+        assert_eq!(format_age(Duration::from_secs(86400)), "1d");
+        assert_eq!(format_age(Duration::from_secs(2 * 86400 + 4 * 3600)), "2d4h");
+    }
+}